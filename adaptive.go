@@ -0,0 +1,133 @@
+package skiplist
+
+import "math/bits"
+
+// The number of insertions and removals
+// between checks of the structure.
+const adaptInterval = 64
+
+// Relink every node of the skiplist with the levels of
+// a perfectly balanced skiplist, where every other node
+// has a level of at least 2, every fourth node a level
+// of at least 3 and so on. The nodes are kept, but their
+// levels change.
+// Complexity: O(n)
+func (l *SkipList[T]) Rebuild() {
+	l.recordOp("rebuild")
+	var (
+		// the last node linked at each level
+		// and its index, with the head at 0.
+		preds   [MaxLevel]*Node[T]
+		predIdx [MaxLevel]int
+		levels  [MaxLevel]int
+	)
+	for levelIdx := range preds {
+		preds[levelIdx] = l.head
+	}
+	idx := 0
+	for node := l.head.lanes[0]; node != l.tail; {
+		// the forward lanes are overwritten
+		// when the next node is linked.
+		next := node.lanes[0]
+		idx++
+		level := 1 + bits.TrailingZeros(uint(idx))
+		if level > MaxLevel {
+			level = MaxLevel
+		}
+		if len(node.lanes) != level {
+			l.resizeLanes(node, level)
+			if l.ranks {
+				node.meta.spans = make([]int, level)
+			}
+		}
+		for levelIdx := 0; levelIdx < level; levelIdx++ {
+			pred := preds[levelIdx]
+			pred.lanes[levelIdx] = node
+			node.prevs[levelIdx] = pred
+			if l.ranks {
+				pred.meta.spans[levelIdx] = idx - predIdx[levelIdx]
+			}
+			preds[levelIdx] = node
+			predIdx[levelIdx] = idx
+		}
+		levels[level-1]++
+		node = next
+	}
+	for levelIdx, pred := range preds {
+		pred.lanes[levelIdx] = l.tail
+		l.tail.prevs[levelIdx] = pred
+		if l.ranks {
+			pred.meta.spans[levelIdx] = idx + 1 - predIdx[levelIdx]
+		}
+	}
+	if l.adaptive {
+		l.levels = levels
+	}
+	l.generation++
+}
+
+// Rebuild the skiplist if its levels have degenerated
+// so far that a search takes more than twice as many
+// steps as in a perfectly balanced skiplist.
+// Only checked every adaptInterval modifications.
+func (l *SkipList[T]) adapt() {
+	if l.replaying {
+		return
+	}
+	l.adaptOps++
+	if l.adaptOps < adaptInterval || l.length < adaptInterval {
+		return
+	}
+	l.adaptOps = 0
+	// a perfectly balanced skiplist takes about two
+	// steps for each of its log2(n) levels.
+	optimal := 2*bits.Len(uint(l.length)) + 1
+	if l.searchSteps() > 2*float64(optimal) {
+		l.Rebuild()
+	}
+}
+
+// Estimate the number of steps of a search from
+// the number of nodes of each level.
+func (l *SkipList[T]) searchSteps() float64 {
+	steps := 0.0
+	// the number of nodes with a level
+	// greater than the current level.
+	above := 0
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		atOrAbove := above + l.levels[levelIdx]
+		if atOrAbove == 0 {
+			continue
+		}
+		if above == 0 {
+			// the top level is searched from the head.
+			steps += float64(atOrAbove)
+		} else {
+			// the nodes at this level between
+			// two nodes of the level above.
+			steps += float64(atOrAbove) / float64(above)
+		}
+		above = atOrAbove
+	}
+	return steps
+}
+
+var _ Option = (*withAdaptiveLevels)(nil)
+
+type withAdaptiveLevels struct{}
+
+func (o *withAdaptiveLevels) apply(opts *options) {
+	opts.adaptive = true
+}
+
+// Keep track of the levels of the nodes, and Rebuild the
+// skiplist if searches are estimated to take more than
+// twice as many steps as in a perfectly balanced skiplist,
+// such as after removing most of the tall nodes or when
+// the random number generator is biased.
+// The check is performed periodically on Add, AddNode,
+// Remove, RemoveFirst and RemoveLast, making their
+// amortized complexity unchanged.
+func WithAdaptiveLevels() Option {
+	return &withAdaptiveLevels{}
+}
@@ -0,0 +1,56 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuild(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithRanks())
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	addAll(t, sl, data)
+	first := sl.First()
+	sl.Rebuild()
+	require.Same(t, first, sl.First())
+	requireEqual(t, sl, data)
+	for i := range data {
+		require.Equal(t, i, sl.At(i).Value())
+		require.Equal(t, i, sl.IndexOf(sl.At(i)))
+	}
+	s := sl.Stats()
+	require.Equal(t, 10, s.MaxLevel)
+	require.Equal(t, []int{500, 250, 125, 63, 31, 16, 8, 4, 2, 1}, s.Levels)
+	sl.Remove(500)
+	sl.Add(500)
+	requireEqual(t, sl, data)
+
+	sl = skiplist.New(less[int])
+	sl.Rebuild()
+	requireEqual(t, sl, []int{})
+}
+
+func TestAdaptiveLevels(t *testing.T) {
+	// every node gets a level of 1.
+	rng := func() uint32 { return 0 }
+	sl := skiplist.New(less[int], skiplist.WithRng(rng))
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	require.Equal(t, 1, sl.Stats().MaxLevel)
+
+	sl = skiplist.New(less[int], skiplist.WithRng(rng), skiplist.WithAdaptiveLevels())
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	require.Greater(t, sl.Stats().MaxLevel, 5)
+	for i := 0; i < 1000; i += 2 {
+		sl.Remove(i)
+	}
+	require.Equal(t, 500, sl.Length())
+	require.Greater(t, sl.Stats().MaxLevel, 5)
+}
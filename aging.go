@@ -0,0 +1,107 @@
+package skiplist
+
+import "time"
+
+// Waiting time after which the keys of an
+// AgingQueue are rebased to keep their precision.
+const agingRebaseInterval = time.Hour
+
+// An AgingQueue is a priority queue where the effective
+// priority of a value increases with the time it has been
+// waiting, so that low priority values are not starved by
+// a steady stream of high priority values.
+//
+// The effective priority of a value is its priority plus
+// the rate times the seconds it has been waiting. As every
+// waiting value gains priority at the same rate, values are
+// ordered by their priority minus the rate times the time
+// they were pushed, which does not change while they wait.
+// Those keys are periodically rebased in a single pass
+// instead of updating every value as time passes.
+type AgingQueue[T any] struct {
+	list *SkipList[agingEntry[T]]
+	rate float64
+	now  func() time.Time
+	// The time the keys are relative to.
+	epoch time.Time
+	// Orders values with equal keys by push order.
+	seq uint64
+}
+
+type agingEntry[T any] struct {
+	value T
+	key   float64
+	seq   uint64
+}
+
+// Create a priority queue where waiting values gain
+// rate priority per second. The clock defaults to
+// time.Now if nil.
+func NewAgingQueue[T any](rate float64, now func() time.Time) *AgingQueue[T] {
+	if now == nil {
+		now = time.Now
+	}
+	return &AgingQueue[T]{
+		list: New(func(a, b agingEntry[T]) bool {
+			// highest key first, then first pushed.
+			return a.key > b.key || (a.key == b.key && a.seq < b.seq)
+		}),
+		rate:  rate,
+		now:   now,
+		epoch: now(),
+	}
+}
+
+// Get the number of waiting values.
+func (q *AgingQueue[T]) Len() int {
+	return q.list.Length()
+}
+
+// Add a value with the given priority.
+// Average complexity: O(log(n)), amortized over
+// the periodic rebasing of the keys.
+func (q *AgingQueue[T]) Push(value T, priority float64) {
+	elapsed := q.now().Sub(q.epoch)
+	if elapsed > agingRebaseInterval {
+		q.rebase(elapsed)
+		elapsed = 0
+	}
+	q.seq++
+	q.list.Add(agingEntry[T]{
+		value: value,
+		key:   priority - q.rate*elapsed.Seconds(),
+		seq:   q.seq,
+	})
+}
+
+// Remove and return the value with the highest effective
+// priority, or the value pushed first among values with
+// equal effective priorities.
+// Returns false if the queue is empty.
+// Complexity: O(1)
+func (q *AgingQueue[T]) Pop() (value T, ok bool) {
+	node := q.list.RemoveFirst()
+	if node == nil {
+		return value, false
+	}
+	return node.value.value, true
+}
+
+// Get the value that would be returned by Pop
+// without removing it.
+// Complexity: O(1)
+func (q *AgingQueue[T]) Peek() (value T, ok bool) {
+	entry, ok := q.list.FirstValue()
+	return entry.value, ok
+}
+
+// Move the epoch forward, shifting every key by the
+// same amount so that the order is kept.
+// Complexity: O(n)
+func (q *AgingQueue[T]) rebase(elapsed time.Duration) {
+	shift := q.rate * elapsed.Seconds()
+	for node := q.list.head.lanes[0]; node != q.list.tail; node = node.lanes[0] {
+		node.value.key += shift
+	}
+	q.epoch = q.epoch.Add(elapsed)
+}
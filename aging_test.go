@@ -0,0 +1,49 @@
+package skiplist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgingQueue(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	q := skiplist.NewAgingQueue[string](1, clock)
+	_, ok := q.Pop()
+	require.False(t, ok)
+
+	q.Push("low", 0)
+	now = now.Add(5 * time.Second)
+	q.Push("high", 10)
+	q.Push("mid", 3)
+	require.Equal(t, 3, q.Len())
+	// "low" has gained 5, "high" is still ahead.
+	value, ok := q.Peek()
+	require.True(t, ok)
+	require.Equal(t, "high", value)
+	now = now.Add(10 * time.Second)
+	// "low" has an effective priority of 15 and "high"
+	// of 20, but a new value with priority 19 is behind
+	// the waiting "high" and ahead of "low".
+	q.Push("new", 19)
+	for _, expected := range []string{"high", "new", "low", "mid"} {
+		value, ok := q.Pop()
+		require.True(t, ok)
+		require.Equal(t, expected, value)
+	}
+	require.Equal(t, 0, q.Len())
+
+	// equal effective priorities keep push order
+	// across rebasing of the keys.
+	q.Push("a", 0)
+	now = now.Add(3 * time.Hour)
+	q.Push("b", 3*60*60)
+	q.Push("c", 3*60*60-1)
+	for _, expected := range []string{"a", "b", "c"} {
+		value, _ := q.Pop()
+		require.Equal(t, expected, value)
+	}
+}
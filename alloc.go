@@ -0,0 +1,225 @@
+package skiplist
+
+// An Allocator provides the nodes of a skiplist
+// created WithAllocator. Allocators outside of this
+// package set up the lanes of their nodes with InitNode.
+// Nodes are also allocated when the skiplist changes
+// the level of a node, such as by Rebuild, in which case
+// the node takes over the lanes of the allocated node
+// and the allocated node is freed with the old lanes.
+type Allocator[T any] interface {
+	// Get a node with forward and backward lanes for the
+	// given number of levels, all of which must be nil.
+	AllocNode(level int) *Node[T]
+	// Take back a node that is no longer in use.
+	Free(node *Node[T])
+}
+
+// Set up the lanes of a node for an Allocator, using the
+// first half of lanes as its forward lanes and the second
+// half as its backward lanes. The level of the node is
+// half the length of lanes, and every lane must be nil.
+// Panics if the length of lanes is odd or the level is
+// not in the range [1, 32].
+func InitNode[T any](node *Node[T], lanes []*Node[T]) {
+	level := len(lanes) / 2
+	if len(lanes)%2 != 0 || level < 1 || level > MaxLevel {
+		panic("skiplist: node lanes out of range")
+	}
+	node.lanes = lanes[:level:level]
+	node.prevs = lanes[level:]
+}
+
+// Allocate a node with forward and backward
+// lanes for the given number of levels.
+func allocNode[T any](level int) *Node[T] {
+	node := &Node[T]{}
+	// a single allocation holds both the
+	// forward and the backward lanes.
+	InitNode(node, make([]*Node[T], 2*level))
+	return node
+}
+
+// Give the node new lanes for the given number of
+// levels, taken from the allocator WithAllocator.
+// The current lanes are discarded.
+func (l *SkipList[T]) resizeLanes(node *Node[T], level int) {
+	if l.alloc == nil {
+		InitNode(node, make([]*Node[T], 2*level))
+		return
+	}
+	// swap lanes with a node of the new level
+	// and free it along with the old lanes.
+	spare := l.alloc.AllocNode(level)
+	node.lanes, spare.lanes = spare.lanes, node.lanes
+	node.prevs, spare.prevs = spare.prevs, node.prevs
+	l.alloc.Free(spare)
+}
+
+// Clear the node so that it can be reused.
+func freeNode[T any](node *Node[T]) {
+	var zero T
+	node.Reset(zero)
+}
+
+var _ Allocator[int] = (*PoolAllocator[int])(nil)
+
+// A PoolAllocator reuses freed nodes of the same level,
+// allocating new nodes only when there are none to reuse.
+// It is not threadsafe.
+type PoolAllocator[T any] struct {
+	free [MaxLevel][]*Node[T]
+}
+
+// Create an allocator that reuses freed nodes.
+func NewPoolAllocator[T any]() *PoolAllocator[T] {
+	return &PoolAllocator[T]{}
+}
+
+// Get a freed node of the given level,
+// or allocate one if there is none.
+// Complexity: O(1)
+func (a *PoolAllocator[T]) AllocNode(level int) *Node[T] {
+	free := a.free[level-1]
+	if len(free) == 0 {
+		return allocNode[T](level)
+	}
+	node := free[len(free)-1]
+	free[len(free)-1] = nil
+	a.free[level-1] = free[:len(free)-1]
+	return node
+}
+
+// Keep the node for reuse.
+// Complexity: O(1)
+func (a *PoolAllocator[T]) Free(node *Node[T]) {
+	freeNode(node)
+	level := len(node.lanes)
+	a.free[level-1] = append(a.free[level-1], node)
+}
+
+// Get the number of freed nodes kept for reuse.
+func (a *PoolAllocator[T]) Pooled() int {
+	n := 0
+	for _, free := range a.free {
+		n += len(free)
+	}
+	return n
+}
+
+var _ Allocator[int] = (*ArenaAllocator[int])(nil)
+
+// An ArenaAllocator carves nodes and their lanes out of
+// large chunks, reducing the number of allocations and
+// keeping nodes close together in memory. Freed nodes
+// are reused for nodes of the same level, the memory of
+// a chunk is only released once all of its nodes are
+// unreachable. It is not threadsafe.
+type ArenaAllocator[T any] struct {
+	chunkSize int
+	// Unused part of the current node chunk.
+	nodes []Node[T]
+	// Unused part of the current lane chunk.
+	lanes []*Node[T]
+	free  [MaxLevel][]*Node[T]
+	// Total number of nodes in all chunks.
+	capacity int
+	// Number of nodes carved out of the chunks.
+	carved int
+}
+
+// Create an allocator that allocates nodes
+// in chunks of the given number of nodes.
+func NewArenaAllocator[T any](chunkSize int) *ArenaAllocator[T] {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	return &ArenaAllocator[T]{
+		chunkSize: chunkSize,
+	}
+}
+
+// Get a freed node of the given level, or
+// carve one out of the current chunk.
+// Complexity: O(1)
+func (a *ArenaAllocator[T]) AllocNode(level int) *Node[T] {
+	if free := a.free[level-1]; len(free) > 0 {
+		node := free[len(free)-1]
+		free[len(free)-1] = nil
+		a.free[level-1] = free[:len(free)-1]
+		return node
+	}
+	if len(a.nodes) == 0 {
+		a.nodes = make([]Node[T], a.chunkSize)
+		a.capacity += a.chunkSize
+	}
+	if len(a.lanes) < 2*level {
+		// nodes have two levels on average.
+		size := 4 * a.chunkSize
+		if size < 2*level {
+			size = 2 * level
+		}
+		a.lanes = make([]*Node[T], size)
+	}
+	node := &a.nodes[0]
+	a.nodes = a.nodes[1:]
+	InitNode(node, a.lanes[:2*level:2*level])
+	a.lanes = a.lanes[2*level:]
+	a.carved++
+	return node
+}
+
+// Keep the node for reuse.
+// Complexity: O(1)
+func (a *ArenaAllocator[T]) Free(node *Node[T]) {
+	freeNode(node)
+	level := len(node.lanes)
+	a.free[level-1] = append(a.free[level-1], node)
+}
+
+// Get the number of freed nodes kept for reuse.
+func (a *ArenaAllocator[T]) Pooled() int {
+	n := 0
+	for _, free := range a.free {
+		n += len(free)
+	}
+	return n
+}
+
+// Get the number of nodes that are in use,
+// and the total number of nodes in all chunks.
+func (a *ArenaAllocator[T]) Utilization() (used, capacity int) {
+	return a.carved - a.Pooled(), a.capacity
+}
+
+// Hand a node that has been removed from the skiplist
+// back to its allocator WithAllocator. The node must not
+// be used afterwards. Does nothing without an allocator.
+// Complexity: O(1)
+func (l *SkipList[T]) Free(node *Node[T]) {
+	if l.alloc != nil {
+		l.alloc.Free(node)
+	}
+}
+
+var _ Option = (*withAllocator)(nil)
+
+type withAllocator struct {
+	// An Allocator[T], the type parameter
+	// is not known to the options.
+	alloc any
+}
+
+func (o *withAllocator) apply(opts *options) {
+	opts.alloc = o.alloc
+}
+
+// Obtain the nodes of the skiplist from an allocator.
+// Removed nodes are handed back to the allocator with
+// SkipList.Free.
+// The type parameter must match that of the skiplist.
+func WithAllocator[T any](a Allocator[T]) Option {
+	return &withAllocator{
+		alloc: a,
+	}
+}
@@ -0,0 +1,103 @@
+package skiplist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocator(t *testing.T) {
+	pool := skiplist.NewPoolAllocator[int]()
+	arena := skiplist.NewArenaAllocator[int](16)
+	for name, alloc := range map[string]skiplist.Allocator[int]{
+		"Pool":  pool,
+		"Arena": arena,
+	} {
+		t.Run(name, func(t *testing.T) {
+			sl := skiplist.New(less[int], skiplist.WithAllocator(alloc), skiplist.WithRanks())
+			data := make([]int, 100)
+			for i := range data {
+				data[i] = i
+			}
+			addAll(t, sl, data)
+			for i := 0; i < 50; i++ {
+				sl.Free(sl.RemoveFirst())
+			}
+			requireEqual(t, sl, data[50:])
+			// freed nodes are reused.
+			addAll(t, sl, data[:50])
+			requireEqual(t, sl, data)
+			for i := range data {
+				require.Equal(t, i, sl.At(i).Value())
+			}
+			node := sl.NewNode(-1, 3)
+			require.Equal(t, 3, node.Level())
+			sl.AddNode(node)
+			require.Equal(t, -1, sl.First().Value())
+		})
+	}
+	pooled := pool.Pooled()
+	for i := 0; i < 10; i++ {
+		pool.Free(pool.AllocNode(1))
+	}
+	require.LessOrEqual(t, pool.Pooled(), pooled+1)
+	used, capacity := arena.Utilization()
+	require.Equal(t, 101, used)
+	require.Equal(t, 0, capacity%16)
+	require.GreaterOrEqual(t, capacity, 101)
+	require.Panics(t, func() {
+		skiplist.New(less[int], skiplist.WithAllocator[string](skiplist.NewPoolAllocator[string]()))
+	})
+}
+
+// An allocator outside of the package
+// counting the nodes it hands out.
+type countingAllocator struct {
+	allocated, freed int
+}
+
+func (a *countingAllocator) AllocNode(level int) *skiplist.Node[int] {
+	a.allocated++
+	node := &skiplist.Node[int]{}
+	skiplist.InitNode(node, make([]*skiplist.Node[int], 2*level))
+	return node
+}
+
+func (a *countingAllocator) Free(node *skiplist.Node[int]) {
+	a.freed++
+}
+
+func TestExternalAllocator(t *testing.T) {
+	alloc := &countingAllocator{}
+	sl := skiplist.New(less[int], skiplist.WithAllocator[int](alloc), skiplist.WithRanks())
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	addAll(t, sl, data)
+	require.Equal(t, 100, alloc.allocated)
+	// nodes changing level take their
+	// lanes from the allocator.
+	sl.Rebuild()
+	require.Greater(t, alloc.allocated, 100)
+	require.Equal(t, alloc.allocated-100, alloc.freed)
+	require.NoError(t, sl.CheckStructure())
+	requireEqual(t, sl, data)
+	for i := 0; i < 10; i++ {
+		sl.Free(sl.RemoveFirst())
+	}
+	sl.Add(-1)
+	for stepper := sl.RebuildStepper(); stepper.Advance(time.Second); {
+	}
+	require.Equal(t, alloc.allocated-91, alloc.freed)
+	require.NoError(t, sl.CheckStructure())
+
+	require.Panics(t, func() {
+		skiplist.InitNode(&skiplist.Node[int]{}, make([]*skiplist.Node[int], 3))
+	})
+	require.Panics(t, func() {
+		skiplist.InitNode(&skiplist.Node[int]{}, nil)
+	})
+}
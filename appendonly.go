@@ -0,0 +1,22 @@
+package skiplist
+
+var _ Option = (*withAppendOnly)(nil)
+
+type withAppendOnly struct{}
+
+func (o *withAppendOnly) apply(opts *options) {
+	opts.appendOnly = true
+}
+
+// Declare that values are added in ascending order, such
+// as for log structured ingestion. Add then compares the
+// value with the last value only and appends it in O(1)
+// if it is greater, and Search returns nil in O(1) for
+// values greater than the last value. A value equal to the
+// last value is inserted by searching, keeping equal values
+// in the same order as without WithAppendOnly. A value added
+// out of order is still inserted in order by searching, and
+// logged as a warning WithLogger.
+func WithAppendOnly() Option {
+	return &withAppendOnly{}
+}
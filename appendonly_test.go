@@ -0,0 +1,91 @@
+package skiplist_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendOnly(t *testing.T) {
+	type entry struct {
+		key, id int
+	}
+	comparisons := 0
+	lessKey := func(a, b entry) bool {
+		comparisons++
+		return a.key < b.key
+	}
+	buf := &bytes.Buffer{}
+	sl := skiplist.New(
+		lessKey,
+		skiplist.WithAppendOnly(),
+		skiplist.WithRanks(),
+		skiplist.WithLogger(slog.New(slog.NewTextHandler(buf, nil))),
+	)
+	for i := 0; i < 1000; i++ {
+		comparisons = 0
+		node, _ := sl.Add(entry{key: i, id: i})
+		require.NotNil(t, node)
+		require.LessOrEqual(t, comparisons, 1)
+	}
+	require.NoError(t, sl.CheckStructure())
+
+	comparisons = 0
+	require.Nil(t, sl.Search(entry{key: 1000}))
+	require.Equal(t, 1, comparisons)
+
+	// values out of order are inserted by searching
+	require.Empty(t, buf.String())
+	sl.Add(entry{key: 5, id: -1})
+	require.Contains(t, buf.String(), "out of order")
+	require.Equal(t, entry{key: 5, id: -1}, sl.Search(entry{key: 5}).Value())
+	require.NoError(t, sl.CheckStructure())
+	require.Equal(t, 1001, sl.Length())
+
+	t.Run("Equal", func(t *testing.T) {
+		// equal values are kept in the same order as without
+		// WithAppendOnly, which is the reverse of the order
+		// they were added in.
+		appended := skiplist.New(lessKey, skiplist.WithAppendOnly())
+		searched := skiplist.New(lessKey)
+		for i := 0; i < 100; i++ {
+			appended.Add(entry{key: i / 4, id: i})
+			searched.Add(entry{key: i / 4, id: i})
+		}
+		require.Equal(t, searched.FirstN(100), appended.FirstN(100))
+		require.Equal(t, entry{key: 10, id: 43}, appended.Search(entry{key: 10}).Value())
+	})
+	t.Run("WithComparisonBudget", func(t *testing.T) {
+		sl := skiplist.New(
+			less[int],
+			skiplist.WithAppendOnly(),
+			skiplist.WithComparisonBudget(64),
+		)
+		for i := 0; i < 1000; i++ {
+			_, _, err := sl.TryAdd(i)
+			require.NoError(t, err)
+			sl.Add(i)
+		}
+		require.Equal(t, 2000, sl.Length())
+		require.NoError(t, sl.CheckStructure())
+	})
+
+	t.Run("WithReplace", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithAppendOnly(), skiplist.WithReplace())
+		sl.Add(1)
+		sl.Add(2)
+		_, replaced := sl.Add(2)
+		require.NotNil(t, replaced)
+		require.Equal(t, 2, sl.Length())
+	})
+	t.Run("WithHardLimit", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithAppendOnly(), skiplist.WithHardLimit(1))
+		node, _ := sl.Add(1)
+		require.NotNil(t, node)
+		node, _ = sl.Add(2)
+		require.Nil(t, node)
+	})
+}
@@ -0,0 +1,149 @@
+package skiplist
+
+const (
+	// Bits of the bloom filter per value,
+	// for a false positive rate of about 1%.
+	bloomBitsPerValue = 10
+	// Number of bits set per value.
+	bloomHashes = 7
+	// Number of values the smallest
+	// bloom filter is sized for.
+	bloomMinCapacity = 64
+)
+
+// A bloom filter of the values in a skiplist.
+type bloom[T any] struct {
+	hash func(T) uint64
+	bits []uint64
+	// Number of values the filter is sized for.
+	capacity int
+	// Number of values added since the
+	// filter was last rebuilt.
+	added int
+}
+
+// Get the bit indices of a hash by double hashing.
+func (b *bloom[T]) indices(h uint64, fn func(idx uint64) bool) bool {
+	h1, h2 := h&0xffffffff, h>>32|1
+	m := uint64(len(b.bits)) * 64
+	for i := uint64(0); i < bloomHashes; i++ {
+		if !fn((h1 + i*h2) % m) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add the hash of a value to the filter.
+func (b *bloom[T]) add(h uint64) {
+	b.indices(h, func(idx uint64) bool {
+		b.bits[idx/64] |= 1 << (idx % 64)
+		return true
+	})
+	b.added++
+}
+
+// Check if the value may have been added to the filter.
+func (b *bloom[T]) mayContain(value T) bool {
+	return b.indices(b.hash(value), func(idx uint64) bool {
+		return b.bits[idx/64]&(1<<(idx%64)) != 0
+	})
+}
+
+// Clear the filter and size it for the given
+// number of values.
+func (b *bloom[T]) reset(capacity int) {
+	if capacity < bloomMinCapacity {
+		capacity = bloomMinCapacity
+	}
+	words := (capacity*bloomBitsPerValue + 63) / 64
+	if cap(b.bits) >= words {
+		b.bits = b.bits[:words]
+		for i := range b.bits {
+			b.bits[i] = 0
+		}
+	} else {
+		b.bits = make([]uint64, words)
+	}
+	b.capacity = capacity
+	b.added = 0
+}
+
+// Add the hash of a value to the bloom filter before the
+// value is stored in the skiplist, first rebuilding the
+// filter from the values of the skiplist once more values
+// have been added than it was sized for. Rebuilding also
+// drops the bits of removed values.
+func (l *SkipList[T]) addBloom(h uint64) {
+	if l.bloom.added >= l.bloom.capacity {
+		l.rebuildBloom()
+	}
+	l.bloom.add(h)
+}
+
+// Rebuild the bloom filter from the values
+// of the skiplist, sized for twice as many.
+// The current filter is replaced once every
+// value is hashed, so that a panic of the hash
+// function leaves it unmodified.
+// Complexity: O(n)
+func (l *SkipList[T]) rebuildBloom() {
+	rebuilt := &bloom[T]{hash: l.bloom.hash}
+	rebuilt.reset(2 * l.length)
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		rebuilt.add(rebuilt.hash(node.value))
+	}
+	l.bloom = rebuilt
+}
+
+// Check if the skiplist contains a value equal to the
+// given value. WithBloomFilter, most values that are not
+// in the skiplist are rejected without searching.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Contains(value T) bool {
+	_, ok := l.Get(value)
+	return ok
+}
+
+// Get the first value equal to the given value.
+// Returns false if no such value exists.
+// WithBloomFilter, most values that are not in the
+// skiplist are rejected without searching.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Get(value T) (T, bool) {
+	if l.bloom != nil && !l.bloom.mayContain(value) {
+		var zero T
+		return zero, false
+	}
+	node := l.Search(value)
+	if node == nil || l.less(value, node.value) {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+var _ Option = (*withBloomFilter)(nil)
+
+type withBloomFilter struct {
+	// A func(T) uint64, the type parameter
+	// is not known to the options.
+	hash any
+}
+
+func (o *withBloomFilter) apply(opts *options) {
+	opts.bloomHash = o.hash
+}
+
+// Maintain a bloom filter of the values in the skiplist,
+// consulted by Contains and Get to reject values that are
+// not in the skiplist without searching for them.
+// Equal values must have equal hashes.
+// The filter is rebuilt as the skiplist grows, which also
+// drops the values that have been removed since.
+// The type parameter must match that of the skiplist.
+func WithBloomFilter[T any](hash func(T) uint64) Option {
+	return &withBloomFilter{
+		hash: hash,
+	}
+}
@@ -0,0 +1,58 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	comparisons := 0
+	countingLess := func(a, b int) bool {
+		comparisons++
+		return a < b
+	}
+	hash := func(v int) uint64 { return uint64(v) * 0x9e3779b97f4a7c15 }
+	for _, opts := range [][]skiplist.Option{
+		nil,
+		{skiplist.WithBloomFilter(hash)},
+	} {
+		sl := skiplist.New(countingLess, opts...)
+		for i := 0; i < 1000; i++ {
+			sl.Add(2 * i)
+		}
+		for i := 0; i < 1000; i++ {
+			require.True(t, sl.Contains(2*i))
+			value, ok := sl.Get(2 * i)
+			require.True(t, ok)
+			require.Equal(t, 2*i, value)
+		}
+		comparisons = 0
+		for i := 0; i < 1000; i++ {
+			require.False(t, sl.Contains(2*i+1))
+			_, ok := sl.Get(2*i + 1)
+			require.False(t, ok)
+		}
+		if opts != nil {
+			// only false positives are searched for.
+			require.Less(t, comparisons, 2000)
+		} else {
+			require.Greater(t, comparisons, 2000)
+		}
+		// removed values are no longer contained
+		// and replaced values are found.
+		for i := 0; i < 500; i++ {
+			sl.Remove(2 * i)
+		}
+		require.False(t, sl.Contains(0))
+		node := sl.First()
+		require.True(t, sl.CompareAndUpdate(node, node.Value(), node.Value()-1, func(a, b int) bool { return a == b }))
+		require.True(t, sl.Contains(node.Value()))
+		sl.Clear()
+		require.False(t, sl.Contains(1001))
+	}
+	require.Panics(t, func() {
+		skiplist.New(less[int], skiplist.WithBloomFilter(func(string) uint64 { return 0 }))
+	})
+}
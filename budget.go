@@ -0,0 +1,49 @@
+package skiplist
+
+import "log/slog"
+
+// Compare two values during a search through the skiplist,
+// counting the comparison in the number of comparisons made
+// by the search so far. The count is kept by the caller so
+// that searches do not modify the skiplist.
+// Panics with ErrComparisonBudgetExceeded once the search
+// has made more comparisons than the budget
+// WithComparisonBudget.
+func (l *SkipList[T]) searchLess(comparisons *int, a, b T) bool {
+	if l.budget > 0 {
+		if *comparisons++; *comparisons > l.budget {
+			l.warn("skiplist: comparison budget exceeded", slog.Int("budget", l.budget))
+			panic(ErrComparisonBudgetExceeded)
+		}
+	}
+	return l.less(a, b)
+}
+
+var _ Option = (*withComparisonBudget)(nil)
+
+type withComparisonBudget struct {
+	perOp int
+}
+
+func (o *withComparisonBudget) apply(opts *options) {
+	opts.budget = o.perOp
+}
+
+// Limit the number of comparisons of each search descending
+// through the lanes of the skiplist. A search exceeding the
+// budget panics with ErrComparisonBudgetExceeded, or the
+// Try methods return it wrapped in a PanicError.
+// This catches degenerate structures and broken
+// comparators, as a healthy skiplist of n values needs
+// about 2*log2(n) comparisons per search.
+// Only the descent is limited. Walking from node to node,
+// such as by an Iter, a Scanner or Between, is not.
+// Every search is made before the modification it leads
+// to, so a change exceeding the budget leaves the skiplist
+// unmodified. Operations making several changes, such as
+// ApplySortedDelta, keep the changes made before it.
+func WithComparisonBudget(perOp int) Option {
+	return &withComparisonBudget{
+		perOp: perOp,
+	}
+}
@@ -0,0 +1,118 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/skiplisttest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComparisonBudget(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithComparisonBudget(64))
+	for i := 0; i < 10000; i++ {
+		sl.Add(i)
+	}
+	for i := 0; i < 10000; i++ {
+		require.NotNil(t, sl.Search(i))
+	}
+
+	// every node gets a level of 1.
+	levels := make([]int, 100)
+	for i := range levels {
+		levels[i] = 1
+	}
+	sl = skiplist.New(
+		less[int],
+		skiplist.WithComparisonBudget(64),
+		skiplist.WithRng(skiplisttest.LevelsFromSlice(levels...)),
+	)
+	// added in descending order each value is
+	// only compared with the first value.
+	for i := 99; i >= 0; i-- {
+		sl.Add(i)
+	}
+	require.PanicsWithValue(t, skiplist.ErrComparisonBudgetExceeded, func() {
+		sl.Search(100)
+	})
+	_, _, err := sl.TryAdd(200)
+	require.ErrorIs(t, err, skiplist.ErrComparisonBudgetExceeded)
+	require.Equal(t, 100, sl.Length())
+	require.NoError(t, sl.CheckStructure())
+	// searches that stay within the budget
+	// are not affected by earlier failures.
+	require.NotNil(t, sl.Search(10))
+}
+
+func TestComparisonBudgetScans(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithComparisonBudget(64), skiplist.WithRanks())
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	// walking from node to node is not limited
+	lower, upper := 10, 900
+	it := sl.NewIter(&lower, &upper)
+	n := 0
+	for it.Next() {
+		n++
+	}
+	require.Equal(t, 890, n)
+	s := sl.Scan()
+	for n = 0; s.Next(); n++ {
+	}
+	require.Equal(t, 1000, n)
+	require.Len(t, sl.Between(0, 999, 0, -1), 1000)
+	require.Len(t, sl.MultiSearch(make([]int, 200)), 200)
+	require.Equal(t, []bool{true}, sl.MultiContains([]int{5}))
+}
+
+func TestComparisonBudgetUpdate(t *testing.T) {
+	// every node gets a level of 1.
+	levels := make([]int, 100)
+	for i := range levels {
+		levels[i] = 1
+	}
+	sl := skiplist.New(
+		less[int],
+		skiplist.WithComparisonBudget(64),
+		skiplist.WithRng(skiplisttest.LevelsFromSlice(levels...)),
+	)
+	for i := 99; i >= 0; i-- {
+		sl.Add(i)
+	}
+	node := sl.First()
+	eq := func(a, b int) bool { return a == b }
+	// moving the node searches for its new position
+	// before the node is unlinked.
+	require.PanicsWithValue(t, skiplist.ErrComparisonBudgetExceeded, func() {
+		sl.CompareAndUpdate(node, 0, 200, eq)
+	})
+	require.Same(t, node, sl.First())
+	require.Equal(t, 100, sl.Length())
+	require.NoError(t, sl.CheckStructure())
+	require.True(t, sl.CompareAndUpdate(node, 0, 50, eq))
+	require.Equal(t, 1, sl.First().Value())
+	require.NoError(t, sl.CheckStructure())
+}
+
+func TestConcurrentSearch(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithComparisonBudget(64))
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	// searches do not modify the skiplist
+	// and can be made concurrently.
+	done := make(chan struct{})
+	for g := 0; g < 2; g++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < 1000; i++ {
+				sl.Search(i)
+				sl.SearchDesc(i)
+				sl.CountLess(i)
+			}
+		}()
+	}
+	<-done
+	<-done
+}
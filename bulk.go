@@ -0,0 +1,57 @@
+package skiplist
+
+// Insert a value at the end of the skiplist in O(1) if
+// it is greater than the last value, otherwise insert
+// it like Add.
+// Returns false if the value was less than the last value.
+// Returns a nil node if the skiplist is full WithHardLimit.
+// Average complexity: O(1) if greater than the last
+// value, else O(log(n))
+func (l *SkipList[T]) appendValue(value T) (node *Node[T], inOrder bool) {
+	l.record("add", value)
+	if node, ok := l.appendLast(value); ok {
+		return node, true
+	}
+	inOrder = !l.less(value, l.tail.prevs[0].value)
+	node, _ = l.add(value)
+	return node, inOrder
+}
+
+// Append the value after the last node without searching,
+// if it is greater than the last value.
+// Returns false without modifying the skiplist if the value
+// must be inserted by searching, which is when it is less
+// than or equal to the last value. Equal values are inserted
+// before the existing ones like Add, and WithReplace or
+// WithCounts they are merged with them.
+// Returns a nil node if the skiplist is full WithHardLimit.
+func (l *SkipList[T]) appendLast(value T) (*Node[T], bool) {
+	if last := l.tail.prevs[0]; last != l.head && !l.less(last.value, value) {
+		return nil, false
+	}
+	if l.hardLimit > 0 && l.length >= l.hardLimit {
+		return nil, true
+	}
+	node := l.newNode(value, l.randomLevel())
+	l.appendNode(node)
+	return node, true
+}
+
+// Link a node after the last node of the skiplist.
+// The value of the node must not be less
+// than the value of the last node.
+func (l *SkipList[T]) appendNode(node *Node[T]) {
+	l.linkLast(node, l.callOptions(node.value))
+	l.afterInsert(node)
+}
+
+// Link a node after the last node of the skiplist like
+// appendNode, without evicting values or rebuilding the
+// skiplist.
+func (l *SkipList[T]) linkLast(node *Node[T], calls optionCalls) {
+	// the last node of each level
+	// precedes the appended node.
+	var preds [MaxLevel]*Node[T]
+	copy(preds[:], l.tail.prevs)
+	l.link(node, &preds, calls)
+}
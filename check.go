@@ -0,0 +1,69 @@
+package skiplist
+
+import "fmt"
+
+// Check that the structure of the skiplist is consistent.
+// Every level must link exactly the nodes of at least that
+// level in ascending order, the backward lanes must mirror
+// the forward lanes and WithRanks the spans of the lanes
+// must match. Returns an error describing the first
+// inconsistency found.
+// Complexity: O(n)
+func (l *SkipList[T]) CheckStructure() error {
+	var (
+		// the last node checked at each level
+		// and its index, with the head at 0.
+		preds   [MaxLevel]*Node[T]
+		predIdx [MaxLevel]int
+	)
+	for levelIdx := range preds {
+		preds[levelIdx] = l.head
+	}
+	idx := 0
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		idx++
+		if node == nil {
+			return fmt.Errorf("skiplist: node %d: nil forward lane", idx-1)
+		}
+		if idx > l.length {
+			return fmt.Errorf("skiplist: more nodes than the length %d", l.length)
+		}
+		if len(node.lanes) == 0 || len(node.lanes) != len(node.prevs) {
+			return fmt.Errorf("skiplist: node %d: invalid level", idx)
+		}
+		if prev := preds[0]; prev != l.head {
+			if l.less(node.value, prev.value) {
+				return fmt.Errorf("skiplist: node %d: less than its predecessor", idx)
+			}
+			if (l.replace || l.counts) && !l.less(prev.value, node.value) {
+				return fmt.Errorf("skiplist: node %d: equal to its predecessor", idx)
+			}
+		}
+		for levelIdx := range node.lanes {
+			pred := preds[levelIdx]
+			if pred.lanes[levelIdx] != node {
+				return fmt.Errorf("skiplist: node %d: not linked at level %d", idx, levelIdx+1)
+			}
+			if node.prevs[levelIdx] != pred {
+				return fmt.Errorf("skiplist: node %d: backward lane at level %d does not match", idx, levelIdx+1)
+			}
+			if l.ranks && pred.meta.spans[levelIdx] != idx-predIdx[levelIdx] {
+				return fmt.Errorf("skiplist: node %d: span at level %d does not match", idx, levelIdx+1)
+			}
+			preds[levelIdx] = node
+			predIdx[levelIdx] = idx
+		}
+	}
+	if idx != l.length {
+		return fmt.Errorf("skiplist: %d nodes but a length of %d", idx, l.length)
+	}
+	for levelIdx, pred := range preds {
+		if pred.lanes[levelIdx] != l.tail || l.tail.prevs[levelIdx] != pred {
+			return fmt.Errorf("skiplist: level %d does not end at the tail", levelIdx+1)
+		}
+		if l.ranks && pred.meta.spans[levelIdx] != idx+1-predIdx[levelIdx] {
+			return fmt.Errorf("skiplist: span to the tail at level %d does not match", levelIdx+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStructure(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{
+		nil,
+		{skiplist.WithRanks()},
+		{skiplist.WithReplace()},
+	} {
+		sl := skiplist.New(less[int], opts...)
+		require.NoError(t, sl.CheckStructure())
+		for i := 0; i < 1000; i++ {
+			sl.Add((i * 7919) % 1000)
+		}
+		for i := 0; i < 1000; i += 3 {
+			sl.Remove(i)
+		}
+		require.NoError(t, sl.CheckStructure())
+		sl.Rebuild()
+		require.NoError(t, sl.CheckStructure())
+	}
+	// a comparator that changed its order
+	// after the values were added.
+	reversed := false
+	sl := skiplist.New(func(a, b int) bool {
+		if reversed {
+			return a > b
+		}
+		return a < b
+	})
+	addAll(t, sl, []int{1, 2, 3})
+	reversed = true
+	require.ErrorContains(t, sl.CheckStructure(), "less than its predecessor")
+}
@@ -0,0 +1,185 @@
+// Command skiplist-inspect inspects skiplist structure
+// dumps written by SkipList.DumpStructure.
+//
+// Usage:
+//
+//	skiplist-inspect [-order string|number] stats <dump>
+//	skiplist-inspect [-order string|number] validate <dump>
+//	skiplist-inspect [-order string|number] range <dump> <from> <to>
+//	skiplist-inspect [-order string|number] diff <dump> <dump>
+//
+// Values are handled as the strings they were encoded as,
+// ordered either lexically or numerically. Values that are
+// not numbers are reported as errors in numeric order.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "skiplist-inspect:", err)
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("usage: skiplist-inspect [-order string|number] stats|validate|range|diff <dump> [args]")
+
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("skiplist-inspect", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	order := fs.String("order", "string", "value order, string or number")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	var (
+		less func(a, b string) bool
+		// check that a value can be ordered.
+		check = func(value string) error { return nil }
+	)
+	switch *order {
+	case "string":
+		less = func(a, b string) bool { return a < b }
+	case "number":
+		// every value is checked before it is compared.
+		less = func(a, b string) bool {
+			x, _ := strconv.ParseFloat(a, 64)
+			y, _ := strconv.ParseFloat(b, 64)
+			return x < y
+		}
+		check = func(value string) error {
+			_, err := strconv.ParseFloat(value, 64)
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown order %q", *order)
+	}
+	args = fs.Args()
+	if len(args) < 2 {
+		return errUsage
+	}
+	l, err := load(args[1], less, check)
+	if err != nil {
+		return err
+	}
+	switch cmd := args[0]; {
+	case cmd == "stats" && len(args) == 2:
+		return stats(stdout, l)
+	case cmd == "validate" && len(args) == 2:
+		return validate(stdout, l, less)
+	case cmd == "range" && len(args) == 4:
+		for _, bound := range args[2:] {
+			if err := check(bound); err != nil {
+				return err
+			}
+		}
+		for _, value := range l.Between(args[2], args[3], 0, -1) {
+			fmt.Fprintln(stdout, value)
+		}
+		return nil
+	case cmd == "diff" && len(args) == 3:
+		other, err := load(args[2], less, check)
+		if err != nil {
+			return err
+		}
+		diff(stdout, l, other, less)
+		return nil
+	}
+	return errUsage
+}
+
+// Load a structure dump with values kept as strings,
+// failing for values rejected by check.
+func load(
+	path string,
+	less func(a, b string) bool,
+	check func(value string) error,
+) (*skiplist.SkipList[string], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	l, err := skiplist.LoadStructure(
+		f,
+		less,
+		func(value string) (string, error) { return value, check(value) },
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Print the length and level histogram.
+func stats(w io.Writer, l *skiplist.SkipList[string]) error {
+	s := l.Stats()
+	fmt.Fprintf(w, "length: %d\n", s.Length)
+	fmt.Fprintf(w, "max level: %d\n", s.MaxLevel)
+	if s.Length > 0 {
+		total := 0
+		for levelIdx, count := range s.Levels {
+			total += (levelIdx + 1) * count
+		}
+		fmt.Fprintf(w, "avg level: %.2f\n", float64(total)/float64(s.Length))
+	}
+	for levelIdx, count := range s.Levels {
+		fmt.Fprintf(w, "level %d: %d\n", levelIdx+1, count)
+	}
+	return nil
+}
+
+// Check that the nodes are in order and that
+// every value can be found by searching for it.
+func validate(
+	w io.Writer,
+	l *skiplist.SkipList[string],
+	less func(a, b string) bool,
+) error {
+	violations := 0
+	for node := l.First(); node != nil; node = node.Next() {
+		if prev := node.Prev(); prev != nil && less(node.Value(), prev.Value()) {
+			fmt.Fprintf(w, "out of order: %q after %q\n", node.Value(), prev.Value())
+			violations++
+		}
+		if found := l.Search(node.Value()); found == nil || less(node.Value(), found.Value()) {
+			fmt.Fprintf(w, "not found by search: %q\n", node.Value())
+			violations++
+		}
+	}
+	if violations > 0 {
+		return fmt.Errorf("%d violations", violations)
+	}
+	fmt.Fprintln(w, "ok")
+	return nil
+}
+
+// Print the values only found in a prefixed by "-"
+// and the values only found in b prefixed by "+".
+func diff(
+	w io.Writer,
+	a, b *skiplist.SkipList[string],
+	less func(a, b string) bool,
+) {
+	x, y := a.First(), b.First()
+	for x != nil || y != nil {
+		switch {
+		case y == nil || x != nil && less(x.Value(), y.Value()):
+			fmt.Fprintln(w, "-", x.Value())
+			x = x.Next()
+		case x == nil || less(y.Value(), x.Value()):
+			fmt.Fprintln(w, "+", y.Value())
+			y = y.Next()
+		default:
+			x, y = x.Next(), y.Next()
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDump(t *testing.T, values ...int) string {
+	sl := skiplist.New(func(a, b int) bool { return a < b })
+	for _, v := range values {
+		sl.Add(v)
+	}
+	path := filepath.Join(t.TempDir(), "dump")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, sl.DumpStructure(f, strconv.Itoa))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	a := writeDump(t, 1, 2, 3, 10, 20)
+	b := writeDump(t, 2, 3, 4, 20)
+	output := func(args ...string) (string, error) {
+		buf := &bytes.Buffer{}
+		err := run(args, buf)
+		return buf.String(), err
+	}
+	out, err := output("stats", a)
+	require.NoError(t, err)
+	require.Contains(t, out, "length: 5\n")
+	out, err = output("-order", "number", "validate", a)
+	require.NoError(t, err)
+	require.Equal(t, "ok\n", out)
+	// "10" < "2" lexically
+	out, err = output("validate", a)
+	require.Error(t, err)
+	require.Contains(t, out, "out of order")
+	out, err = output("-order", "number", "range", a, "2", "10")
+	require.NoError(t, err)
+	require.Equal(t, "2\n3\n10\n", out)
+	out, err = output("-order", "number", "diff", a, b)
+	require.NoError(t, err)
+	require.Equal(t, "- 1\n+ 4\n- 10\n", out)
+	_, err = output("stats")
+	require.ErrorIs(t, err, errUsage)
+	// values that are not numbers are errors.
+	_, err = output("-order", "number", "range", a, "2", "x")
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	words := filepath.Join(t.TempDir(), "words")
+	require.NoError(t, os.WriteFile(words, []byte("skiplist structure v1\n1 \"x\"\n"), 0o600))
+	_, err = output("-order", "number", "stats", words)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	out, err = output("stats", words)
+	require.NoError(t, err)
+	require.Equal(t, "length: 1\nmax level: 1\navg level: 1.00\nlevel 1: 1\n", out)
+	_, err = output("-order", "reverse", "stats", a)
+	require.Error(t, err)
+	_, err = output("stats", filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
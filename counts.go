@@ -0,0 +1,80 @@
+package skiplist
+
+// Get the number of occurrences of the value held by the
+// node. The count is always 1 unless the skiplist was
+// created WithCounts.
+func (n *Node[T]) Count() int {
+	if n.meta == nil {
+		return 1
+	}
+	return 1 + n.meta.dups
+}
+
+// Get the number of occurrences of a value.
+// Average complexity: O(log(n)) WithCounts or
+// WithReplace, else O(log(n) + k) where k
+// is the number of occurrences.
+func (l *SkipList[T]) CountOf(value T) (count int) {
+	for node := l.Search(value); node != nil && !l.less(value, node.value); node = node.Next() {
+		count += node.Count()
+		if l.counts || l.replace {
+			break
+		}
+	}
+	return count
+}
+
+// Remove a single occurrence of the value held by the
+// node, unlinking the node when no occurrences remain.
+func (l *SkipList[T]) release(node *Node[T]) {
+	if l.counts && node.meta.dups > 0 {
+		l.addDups(node, -1, l.weighOf(node.value))
+		return
+	}
+	l.unlink(node)
+	if l.adaptive {
+		l.adapt()
+	}
+}
+
+// Add occurrences of the value of a node in the
+// skiplist, which counts as an update of the node,
+// given the weight of a single occurrence.
+func (l *SkipList[T]) addOccurrences(node *Node[T], count int, weight int) {
+	l.addDups(node, count, weight)
+	if l.versions {
+		l.stamp(node)
+	}
+	l.touch(node)
+	l.afterInsert(node)
+}
+
+// Add to the number of additional occurrences of the
+// value held by the node, given the weight of a single
+// occurrence.
+func (l *SkipList[T]) addDups(node *Node[T], dups int, weight int) {
+	l.weight += weight * dups
+	node.meta.dups += dups
+}
+
+var _ Option = (*withCounts)(nil)
+
+type withCounts struct{}
+
+func (o *withCounts) apply(opts *options) {
+	opts.counts = true
+}
+
+// Collapse equal values into a single node that keeps
+// count of its occurrences, see Node.Count and CountOf.
+// Adding a value that already exists increments the
+// count of the existing node and returns it.
+// Remove, RemoveFirst and RemoveLast remove a single
+// occurrence, only unlinking the node when its count
+// reaches zero, while RemoveFrom and ForEachSafe always
+// unlink the node along with all of its occurrences.
+// Length reports the number of nodes, not occurrences.
+// Replaces WithReplace.
+func WithCounts() Option {
+	return &withCounts{}
+}
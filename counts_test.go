@@ -0,0 +1,77 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounts(t *testing.T) {
+	const numElem = 1 << 10
+	sortedData := make([]int, numElem)
+	for i := range sortedData {
+		sortedData[i] = i
+	}
+	sl := skiplist.New(less[int], skiplist.WithCounts())
+	for i := range sortedData {
+		for j := 0; j <= i%4; j++ {
+			node, replaced := sl.Add(sortedData[i])
+			require.NotNil(t, node)
+			require.Nil(t, replaced)
+			require.Equal(t, j+1, node.Count())
+		}
+	}
+	requireEqual(t, sl, sortedData)
+	for i := range sortedData {
+		require.Equal(t, i%4+1, sl.CountOf(sortedData[i]))
+	}
+	require.Equal(t, 0, sl.CountOf(-1))
+	// removing single occurrences
+	for i := range sortedData {
+		for j := i % 4; j > 0; j-- {
+			node := sl.Remove(sortedData[i])
+			require.NotNil(t, node)
+			require.Equal(t, j, node.Count())
+		}
+	}
+	requireEqual(t, sl, sortedData)
+	for i := range sortedData {
+		require.Equal(t, 1, sl.CountOf(sortedData[i]))
+	}
+	t.Run("RemoveFirst", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithCounts())
+		addAll(t, sl, []int{1, 1, 2})
+		require.Equal(t, 2, sl.Length())
+		require.Equal(t, 1, sl.RemoveFirst().Value())
+		require.Equal(t, 2, sl.Length())
+		require.Equal(t, 1, sl.RemoveFirst().Value())
+		require.Equal(t, 1, sl.Length())
+		require.Equal(t, 2, sl.RemoveLast().Value())
+		require.Equal(t, 0, sl.Length())
+	})
+	t.Run("RemoveFrom", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithCounts())
+		addAll(t, sl, []int{1, 1, 1, 2})
+		node := sl.Search(1)
+		require.Equal(t, 3, node.Count())
+		require.Equal(t, node, node.RemoveFrom(sl))
+		require.Equal(t, 0, sl.CountOf(1))
+		requireEqual(t, sl, []int{2})
+		// reinserting the node adds its occurrences
+		node.Reset(2)
+		require.Equal(t, 1, node.Count())
+		existing := sl.AddNode(node)
+		require.NotNil(t, existing)
+		require.Equal(t, 2, existing.Count())
+		require.Equal(t, 2, sl.CountOf(2))
+	})
+	t.Run("WithoutCounts", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, []int{1, 1, 1, 2})
+		require.Equal(t, 3, sl.CountOf(1))
+		require.Equal(t, 1, sl.CountOf(2))
+		require.Equal(t, 0, sl.CountOf(3))
+		require.Equal(t, 1, sl.First().Count())
+	})
+}
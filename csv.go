@@ -0,0 +1,62 @@
+package skiplist
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// Write the values in ascending order as CSV records,
+// using record to map each value to its fields.
+func (l *SkipList[T]) WriteCSV(
+	w io.Writer,
+	record func(value T) []string,
+) error {
+	cw := csv.NewWriter(w)
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		if err := cw.Write(record(node.value)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Read CSV records and add the values parsed from them
+// to the skiplist. The record passed to parse is reused
+// for the next record, so parse must not retain it.
+// Values are appended in O(1) for as long as they are
+// in ascending order. Once a value is out of order the
+// remaining values are added like Add.
+// Returns whether all values were in ascending order and
+// thereby appended in O(1).
+// Returns ErrFull if the skiplist is full WithHardLimit.
+func (l *SkipList[T]) ReadCSV(
+	r io.Reader,
+	parse func(record []string) (T, error),
+) (sorted bool, err error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	sorted = true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return sorted, nil
+		}
+		if err != nil {
+			return sorted, err
+		}
+		value, err := parse(record)
+		if err != nil {
+			return sorted, err
+		}
+		var node *Node[T]
+		if sorted {
+			node, sorted = l.appendValue(value)
+		} else {
+			node, _ = l.Add(value)
+		}
+		if node == nil {
+			return sorted, ErrFull
+		}
+	}
+}
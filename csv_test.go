@@ -0,0 +1,66 @@
+package skiplist_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	const numElem = 1 << 12
+	sortedData := make([]int, numElem)
+	for i := range sortedData {
+		sortedData[i] = i
+	}
+	record := func(v int) []string { return []string{strconv.Itoa(v), "x"} }
+	parse := func(record []string) (int, error) { return strconv.Atoi(record[0]) }
+	t.Run("Sorted", func(t *testing.T) {
+		for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+			sl := skiplist.New(less[int], opts...)
+			addAll(t, sl, sortedData)
+			buf := &bytes.Buffer{}
+			require.NoError(t, sl.WriteCSV(buf, record))
+			sl = skiplist.New(less[int], opts...)
+			sorted, err := sl.ReadCSV(buf, parse)
+			require.NoError(t, err)
+			require.True(t, sorted)
+			requireEqual(t, sl, sortedData)
+			for i := range sortedData {
+				require.Equal(t, sortedData[i], sl.Search(sortedData[i]).Value())
+				require.Equal(t, sortedData[i], sl.At(i).Value())
+			}
+		}
+	})
+	t.Run("Unsorted", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		sorted, err := sl.ReadCSV(strings.NewReader("1\n2\n5\n3\n4\n0\n"), parse)
+		require.NoError(t, err)
+		require.False(t, sorted)
+		requireEqual(t, sl, []int{0, 1, 2, 3, 4, 5})
+	})
+	t.Run("Duplicates", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithReplace())
+		sorted, err := sl.ReadCSV(strings.NewReader("1\n1\n2\n2\n"), parse)
+		require.NoError(t, err)
+		require.True(t, sorted)
+		requireEqual(t, sl, []int{1, 2})
+	})
+	t.Run("Errors", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		_, err := sl.ReadCSV(strings.NewReader("1\nx\n"), parse)
+		require.ErrorIs(t, err, strconv.ErrSyntax)
+		_, err = sl.ReadCSV(strings.NewReader("1\n\"x\n"), parse)
+		require.Error(t, err)
+		errWrite := errors.New("write failed")
+		require.ErrorIs(t, sl.WriteCSV(failingWriter{errWrite}, record), errWrite)
+	})
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
@@ -0,0 +1,71 @@
+package skiplist
+
+// A change to apply to a skiplist with ApplySortedDelta.
+type Delta[T any] struct {
+	Value T
+	// Remove the first value equal to Value instead
+	// of inserting or replacing it.
+	Delete bool
+}
+
+// Apply a sequence of changes ordered by their values in
+// a single forward pass over the skiplist. A change either
+// removes the first value equal to its value, if any, or
+// replaces the first equal value with its value, inserting
+// it if there is no equal value. The sequence has the
+// signature of an iter.Seq[Delta[T]].
+// A change out of order is applied with a search from the
+// start of the skiplist instead.
+// Stops and returns ErrFull if a value cannot be inserted
+// WithHardLimit.
+// Complexity: O(n + d) for d changes
+func (l *SkipList[T]) ApplySortedDelta(delta func(yield func(Delta[T]) bool)) (err error) {
+	// the last node with a value less than
+	// the value of the change for each level.
+	var preds [MaxLevel]*Node[T]
+	for levelIdx := range preds {
+		preds[levelIdx] = l.head
+	}
+	delta(func(d Delta[T]) bool {
+		if preds[0] != l.head && !l.less(preds[0].value, d.Value) {
+			l.searchPreds(d.Value, &preds)
+		}
+		for next := preds[0].lanes[0]; next != l.tail && l.less(next.value, d.Value); next = preds[0].lanes[0] {
+			for levelIdx := range next.lanes {
+				preds[levelIdx] = next
+			}
+		}
+		node := l.equalSucc(d.Value, &preds)
+		// the generation expected after applying the
+		// change, unless it caused further removals
+		// or a rebuild that invalidate the predecessors.
+		var generation uint64
+		switch {
+		case d.Delete && node == nil:
+			return true
+		case d.Delete:
+			generation = l.generation + 1
+			l.recordNode("remove", node)
+			l.release(node)
+		case node != nil:
+			generation = l.generation
+			l.update(node, d.Value)
+		default:
+			l.record("add", d.Value)
+			if l.full(d.Value, &preds) {
+				err = ErrFull
+				return false
+			}
+			calls := l.callOptions(d.Value)
+			node = l.newNode(d.Value, l.randomLevel())
+			l.link(node, &preds, calls)
+			generation = l.generation
+			l.afterInsert(node)
+		}
+		if l.generation > generation {
+			l.searchPreds(d.Value, &preds)
+		}
+		return true
+	})
+	return err
+}
@@ -0,0 +1,68 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySortedDelta(t *testing.T) {
+	deltas := func(changes ...skiplist.Delta[[2]int]) func(yield func(skiplist.Delta[[2]int]) bool) {
+		return func(yield func(skiplist.Delta[[2]int]) bool) {
+			for _, d := range changes {
+				if !yield(d) {
+					return
+				}
+			}
+		}
+	}
+	upsert := func(key, value int) skiplist.Delta[[2]int] {
+		return skiplist.Delta[[2]int]{Value: [2]int{key, value}}
+	}
+	remove := func(key int) skiplist.Delta[[2]int] {
+		return skiplist.Delta[[2]int]{Value: [2]int{key, 0}, Delete: true}
+	}
+	lessKey := func(a, b [2]int) bool { return a[0] < b[0] }
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}, {skiplist.WithReplace()}} {
+		sl := skiplist.New(lessKey, opts...)
+		for i := 0; i < 100; i += 2 {
+			sl.Add([2]int{i, 0})
+		}
+		require.NoError(t, sl.ApplySortedDelta(deltas(
+			upsert(-1, 1),
+			remove(0),
+			remove(1),
+			upsert(2, 1),
+			upsert(3, 1),
+			remove(50),
+			upsert(51, 1),
+			// out of order
+			upsert(5, 1),
+			upsert(200, 1),
+		)))
+		require.NoError(t, sl.CheckStructure())
+		expected := map[int]int{-1: 1, 2: 1, 3: 1, 5: 1, 51: 1, 200: 1}
+		for i := 4; i < 100; i += 2 {
+			if i != 50 {
+				expected[i] = 0
+			}
+		}
+		require.Equal(t, len(expected), sl.Length())
+		for node := sl.First(); node != nil; node = node.Next() {
+			value, ok := expected[node.Value()[0]]
+			require.True(t, ok, node.Value())
+			require.Equal(t, value, node.Value()[1], node.Value())
+		}
+	}
+
+	// evictions during the pass
+	sl := skiplist.New(lessKey, skiplist.WithWeightLimit(func([2]int) int { return 1 }, 3, skiplist.Front))
+	require.NoError(t, sl.ApplySortedDelta(deltas(upsert(1, 0), upsert(2, 0), upsert(3, 0), upsert(4, 0), upsert(5, 0))))
+	require.NoError(t, sl.CheckStructure())
+	require.Equal(t, [][2]int{{3, 0}, {4, 0}, {5, 0}}, sl.FirstN(3))
+
+	sl = skiplist.New(lessKey, skiplist.WithHardLimit(2))
+	require.ErrorIs(t, sl.ApplySortedDelta(deltas(upsert(1, 0), upsert(2, 0), upsert(3, 0))), skiplist.ErrFull)
+	require.Equal(t, 2, sl.Length())
+}
@@ -0,0 +1,97 @@
+package skiplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// First line of a structure dump.
+const dumpHeader = "skiplist structure v1"
+
+// Write a diagnostic dump of the skiplist that captures
+// the order and level of every node, so that the exact
+// structure can be recreated with LoadStructure.
+// Each node is written on its own line as its level
+// followed by its quoted value as encoded by encode.
+// Complexity: O(n)
+func (l *SkipList[T]) DumpStructure(
+	w io.Writer,
+	encode func(value T) string,
+) error {
+	bw := bufio.NewWriter(w)
+	if _, err := fmt.Fprintln(bw, dumpHeader); err != nil {
+		return err
+	}
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		_, err := fmt.Fprintf(bw, "%d %s\n", len(node.lanes), strconv.Quote(encode(node.value)))
+		if err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Recreate a skiplist from a dump written by DumpStructure.
+// The nodes are linked in the order and with the levels of
+// the dump without comparing their values, so a dump of a
+// skiplist with a broken order is reproduced exactly.
+// No values are evicted and the skiplist is not rebuilt
+// while loading, instead ErrOverweight is returned if
+// the values weigh more than the limit WithWeightLimit.
+// Complexity: O(n)
+func LoadStructure[T any](
+	r io.Reader,
+	less func(a, b T) bool,
+	decode func(value string) (T, error),
+	opts ...Option,
+) (*SkipList[T], error) {
+	l := New(less, opts...)
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			if lineNum == 1 {
+				return nil, fmt.Errorf("skiplist: structure dump is empty")
+			}
+			return l, nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if lineNum == 1 {
+			if line != dumpHeader {
+				return nil, fmt.Errorf("skiplist: invalid structure dump header %q", line)
+			}
+			continue
+		}
+		levelStr, quoted, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("skiplist: line %d: missing value", lineNum)
+		}
+		level, err := strconv.Atoi(levelStr)
+		if err != nil || level < 1 || level > MaxLevel {
+			return nil, fmt.Errorf("skiplist: line %d: invalid level %q", lineNum, levelStr)
+		}
+		encoded, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("skiplist: line %d: %w", lineNum, err)
+		}
+		value, err := decode(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("skiplist: line %d: %w", lineNum, err)
+		}
+		if l.hardLimit > 0 && l.length >= l.hardLimit {
+			return nil, fmt.Errorf("skiplist: line %d: %w", lineNum, ErrFull)
+		}
+		calls := l.callOptions(value)
+		if l.weigh != nil && l.weight+calls.weight > l.weightLimit {
+			return nil, fmt.Errorf("skiplist: line %d: %w", lineNum, ErrOverweight)
+		}
+		l.record("append", value, levelStr)
+		l.linkLast(l.newNode(value, level), calls)
+	}
+}
@@ -0,0 +1,94 @@
+package skiplist_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpStructure(t *testing.T) {
+	sortedData := make([]int, 1024)
+	for i := range sortedData {
+		sortedData[i] = i
+	}
+	sl := skiplist.New(less[int])
+	addAll(t, sl, sortedData)
+	buf := &bytes.Buffer{}
+	require.NoError(t, sl.DumpStructure(buf, strconv.Itoa))
+	loaded, err := skiplist.LoadStructure(buf, less[int], strconv.Atoi)
+	require.NoError(t, err)
+	requireEqual(t, loaded, sortedData)
+	for a, b := sl.First(), loaded.First(); a != nil; a, b = a.Next(), b.Next() {
+		require.Equal(t, a.Level(), b.Level())
+	}
+	for i := range sortedData {
+		require.Equal(t, sortedData[i], loaded.Search(sortedData[i]).Value())
+	}
+	t.Run("BrokenOrder", func(t *testing.T) {
+		dump := "skiplist structure v1\n2 \"3\"\n1 \"1\"\n3 \"2\"\n"
+		loaded, err := skiplist.LoadStructure(strings.NewReader(dump), less[int], strconv.Atoi)
+		require.NoError(t, err)
+		requireEqual(t, loaded, []int{3, 1, 2})
+		buf := &bytes.Buffer{}
+		require.NoError(t, loaded.DumpStructure(buf, strconv.Itoa))
+		require.Equal(t, dump, buf.String())
+	})
+	t.Run("Strings", func(t *testing.T) {
+		sl := skiplist.New(less[string])
+		addAll(t, sl, []string{"a b", "line\nbreak", "\"quoted\""})
+		identity := func(s string) string { return s }
+		buf := &bytes.Buffer{}
+		require.NoError(t, sl.DumpStructure(buf, identity))
+		loaded, err := skiplist.LoadStructure(
+			buf,
+			less[string],
+			func(s string) (string, error) { return s, nil },
+		)
+		require.NoError(t, err)
+		requireEqual(t, loaded, []string{"\"quoted\"", "a b", "line\nbreak"})
+	})
+	t.Run("WithWeightLimit", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		data := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		addAll(t, sl, data)
+		buf := &bytes.Buffer{}
+		require.NoError(t, sl.DumpStructure(buf, strconv.Itoa))
+		dump := buf.String()
+		weigh := func(int) int { return 1 }
+		// values are not evicted while loading.
+		_, err := skiplist.LoadStructure(
+			strings.NewReader(dump),
+			less[int],
+			strconv.Atoi,
+			skiplist.WithWeightLimit(weigh, len(data)-1, skiplist.Back),
+		)
+		require.ErrorIs(t, err, skiplist.ErrOverweight)
+		loaded, err := skiplist.LoadStructure(
+			strings.NewReader(dump),
+			less[int],
+			strconv.Atoi,
+			skiplist.WithWeightLimit(weigh, len(data), skiplist.Back),
+		)
+		require.NoError(t, err)
+		requireEqual(t, loaded, data)
+		require.Equal(t, len(data), loaded.Weight())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, dump := range []string{
+			"",
+			"not a dump\n",
+			"skiplist structure v1\n1\n",
+			"skiplist structure v1\n0 \"1\"\n",
+			"skiplist structure v1\n33 \"1\"\n",
+			"skiplist structure v1\n1 1\n",
+			"skiplist structure v1\n1 \"x\"\n",
+		} {
+			_, err := skiplist.LoadStructure(strings.NewReader(dump), less[int], strconv.Atoi)
+			require.Error(t, err, dump)
+		}
+	})
+}
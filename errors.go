@@ -0,0 +1,61 @@
+package skiplist
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Returned by iterators of a skiplist created with
+// WithFailFast when the skiplist has been modified
+// since the iterator was created or repositioned.
+var ErrConcurrentModification = errors.New("skiplist: concurrent modification")
+
+// Returned when adding a value to a skiplist
+// that is full WithHardLimit.
+var ErrFull = errors.New("skiplist: full")
+
+// Returned by LoadStructure when the values of the dump
+// weigh more than the limit WithWeightLimit.
+var ErrOverweight = errors.New("skiplist: weight limit exceeded")
+
+// Returned by the Find methods when the skiplist is empty.
+var ErrEmpty = errors.New("skiplist: empty")
+
+// Returned by the Find methods when the skiplist is not
+// empty but no value satisfies the query.
+var ErrNotFound = errors.New("skiplist: not found")
+
+// Passed to panic when a search exceeds its budget
+// WithComparisonBudget. The Try methods return it
+// wrapped in a PanicError.
+var ErrComparisonBudgetExceeded = errors.New("skiplist: comparison budget exceeded")
+
+// Returned by the Try methods of a skiplist when the
+// comparator or a function given to an option, such as
+// the weight function of WithWeightLimit, panics.
+type PanicError struct {
+	// The value passed to panic.
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("skiplist: recovered from panic: %v", e.Value)
+}
+
+// Returns the value passed to panic
+// if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover from a panic and store it as a
+// PanicError in err.
+// Must be called directly by a deferred call.
+func (l *SkipList[T]) recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{Value: r}
+		l.warn("skiplist: recovered from panic", slog.Any("panic", r))
+	}
+}
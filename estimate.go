@@ -0,0 +1,52 @@
+package skiplist
+
+import "math"
+
+// Sizes of the parts of a skiplist in bytes on 64-bit
+// platforms, as used by EstimateMemory.
+const (
+	// The size of a node excluding its value and lanes.
+	NodeBytes = 56
+	// The size of the forward and the backward lane
+	// of each level of a node.
+	LaneBytes = 16
+	// The size of the span of each level of a
+	// node WithRanks.
+	SpanBytes = 8
+	// The size of the metadata of a node WithRanks,
+	// WithCounts, WithVersions or WithTimestamps.
+	MetaBytes = 48
+	// The size of the times of a node WithTimestamps.
+	TimesBytes = 64
+	// The average level of a node, as the level of a node
+	// is one plus the number of times a fair coin comes up
+	// heads in a row.
+	AverageLevel = 2
+)
+
+// Estimate the average number of comparisons of a search
+// in a skiplist of n values, which is about two for each
+// of its log2(n) levels.
+// Returns 0 if n is less than 1.
+func EstimateSearchCost(n int) float64 {
+	if n < 1 {
+		return 0
+	}
+	return 2*math.Log2(float64(n)) + 1
+}
+
+// Estimate the number of bytes of memory used by a
+// skiplist of n values created without options, where
+// avgValueSize is the average size of a value, including
+// any memory it references such as the bytes of a string.
+// Add n*MetaBytes WithRanks, WithCounts, WithVersions or
+// WithTimestamps, n*AverageLevel*SpanBytes WithRanks and
+// n*TimesBytes WithTimestamps.
+// The estimate does not include the rounding up of
+// allocations by the runtime.
+func EstimateMemory(n int, avgValueSize int) int {
+	// the head and tail sentinels have lanes
+	// in a single direction for every level.
+	sentinels := 2 * (NodeBytes + MaxLevel*LaneBytes/2)
+	return sentinels + n*(NodeBytes+avgValueSize+AverageLevel*LaneBytes)
+}
@@ -0,0 +1,130 @@
+package skiplist_test
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/gen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Run("SearchCost", func(t *testing.T) {
+		require.Zero(t, skiplist.EstimateSearchCost(0))
+		const n = 1 << 14
+		comparisons := 0
+		sl := skiplist.New(func(a, b int) bool {
+			comparisons++
+			return a < b
+		})
+		for i := 0; i < n; i++ {
+			sl.Add(i)
+		}
+		comparisons = 0
+		rng := rand.New(rand.NewSource(0))
+		const searches = 1000
+		for i := 0; i < searches; i++ {
+			sl.Search(rng.Intn(n))
+		}
+		estimate := skiplist.EstimateSearchCost(n)
+		require.InDelta(t, estimate, float64(comparisons)/searches, estimate/4)
+	})
+	t.Run("Memory", func(t *testing.T) {
+		require.Equal(t, skiplist.NodeBytes, int(unsafe.Sizeof(skiplist.Node[struct{}]{})))
+		require.Equal(t, skiplist.NodeBytes+8, int(unsafe.Sizeof(skiplist.Node[int]{})))
+		const n = 1 << 16
+		sl := skiplist.New(less[int])
+		for i := 0; i < n; i++ {
+			sl.Add(i)
+		}
+		// the memory of the nodes of the skiplist,
+		// counted from the levels of its nodes.
+		lanes := 0
+		for levelIdx, count := range sl.Stats().Levels {
+			lanes += (levelIdx + 1) * count
+		}
+		sentinels := skiplist.EstimateMemory(0, 8)
+		used := sentinels + n*(skiplist.NodeBytes+8) + lanes*skiplist.LaneBytes
+		estimate := skiplist.EstimateMemory(n, 8)
+		require.InDelta(t, used, estimate, float64(estimate)/100)
+	})
+	t.Run("Allocated", func(t *testing.T) {
+		// compare with the memory allocated by the
+		// runtime, which rounds allocations up.
+		const n = 1 << 14
+		for _, tc := range []struct {
+			name  string
+			opts  []skiplist.Option
+			extra int
+		}{
+			{"Default", nil, 0},
+			{"WithRanks", []skiplist.Option{skiplist.WithRanks()}, skiplist.MetaBytes + skiplist.AverageLevel*skiplist.SpanBytes},
+			{"WithTimestamps", []skiplist.Option{skiplist.WithTimestamps()}, skiplist.MetaBytes + skiplist.TimesBytes},
+		} {
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			sl := skiplist.New(less[int], tc.opts...)
+			for i := 0; i < n; i++ {
+				sl.Add(i)
+			}
+			runtime.ReadMemStats(&after)
+			runtime.KeepAlive(sl)
+			allocated := float64(after.TotalAlloc - before.TotalAlloc)
+			estimate := skiplist.EstimateMemory(n, 8) + n*tc.extra
+			require.InEpsilon(t, estimate, allocated, 0.1, tc.name)
+		}
+	})
+}
+
+// Compare the estimated memory with the memory
+// allocated by the runtime, including the rounding
+// up of allocations.
+func BenchmarkEstimateMemory(b *testing.B) {
+	const n = 1 << 16
+	var before, after runtime.MemStats
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		sl := skiplist.New(less[int])
+		gen.Sequential(1, n, 0)(func(key int) bool {
+			sl.Add(key)
+			return true
+		})
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(sl)
+	}
+	used := after.TotalAlloc - before.TotalAlloc
+	b.ReportMetric(float64(used)/float64(skiplist.EstimateMemory(n, 8)), "used/estimate")
+}
+
+// Fail if the memory of a skiplist created without options
+// grows, as every skiplist pays for the fields of a node.
+// A node of an int value fits the 64 byte size class, and
+// the lanes of a node take about 32 bytes on average.
+func BenchmarkDefaultMemory(b *testing.B) {
+	const (
+		n                = 1 << 16
+		maxBytesPerValue = 104
+	)
+	var before, after runtime.MemStats
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		sl := skiplist.New(less[int])
+		gen.Sequential(1, n, 0)(func(key int) bool {
+			sl.Add(key)
+			return true
+		})
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(sl)
+	}
+	perValue := float64(after.TotalAlloc-before.TotalAlloc) / n
+	b.ReportMetric(perValue, "B/value")
+	if perValue > maxBytesPerValue {
+		b.Fatalf("%.1f bytes per value, expected at most %d", perValue, maxBytesPerValue)
+	}
+}
@@ -0,0 +1,32 @@
+package skiplist
+
+// Walk the skiplist once in ascending order and extract a
+// column of fields from the values for each given function.
+// Column i holds the results of extract[i] for every value.
+// Complexity: O(n*len(extract))
+func (l *SkipList[T]) ExportColumns(extract ...func(T) any) [][]any {
+	columns := make([][]any, len(extract))
+	for i := range columns {
+		columns[i] = make([]any, 0, l.length)
+	}
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		for i := range extract {
+			columns[i] = append(columns[i], extract[i](node.value))
+		}
+	}
+	return columns
+}
+
+// Extract a typed column of fields from the values
+// of the skiplist in ascending order.
+// Complexity: O(n)
+func ExportColumn[T any, C any](
+	l *SkipList[T],
+	extract func(T) C,
+) []C {
+	column := make([]C, 0, l.length)
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		column = append(column, extract(node.value))
+	}
+	return column
+}
@@ -0,0 +1,35 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	id    int
+	name  string
+	score float64
+}
+
+func lessRow(a, b row) bool { return a.id < b.id }
+
+func TestExportColumns(t *testing.T) {
+	sl := skiplist.New(lessRow)
+	require.Equal(t, [][]any{{}}, sl.ExportColumns(func(r row) any { return r.id }))
+	addAll(t, sl, []row{{2, "b", 0.5}, {1, "a", 1.5}, {3, "c", 2.5}})
+	columns := sl.ExportColumns(
+		func(r row) any { return r.id },
+		func(r row) any { return r.name },
+	)
+	require.Equal(t, [][]any{{1, 2, 3}, {"a", "b", "c"}}, columns)
+	require.Empty(t, sl.ExportColumns())
+}
+
+func TestExportColumn(t *testing.T) {
+	sl := skiplist.New(lessRow)
+	addAll(t, sl, []row{{2, "b", 0.5}, {1, "a", 1.5}, {3, "c", 2.5}})
+	scores := skiplist.ExportColumn(sl, func(r row) float64 { return r.score })
+	require.Equal(t, []float64{1.5, 0.5, 2.5}, scores)
+}
@@ -0,0 +1,50 @@
+package skiplist
+
+// Find the first node with a value equal to the given
+// value. Unlike Get, the error tells whether the skiplist
+// is empty, ErrEmpty, or the value is not in it,
+// ErrNotFound.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Find(value T) (*Node[T], error) {
+	if l.length == 0 {
+		return nil, ErrEmpty
+	}
+	if l.bloom != nil && !l.bloom.mayContain(value) {
+		return nil, ErrNotFound
+	}
+	node := l.Search(value)
+	if node == nil || l.less(value, node.value) {
+		return nil, ErrNotFound
+	}
+	return node, nil
+}
+
+// Find the first node with a value greater than or equal
+// to the given value like Search. The error tells whether
+// the skiplist is empty, ErrEmpty, or every value is less
+// than the given value, ErrNotFound.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) FindGE(value T) (*Node[T], error) {
+	if l.length == 0 {
+		return nil, ErrEmpty
+	}
+	if node := l.Search(value); node != nil {
+		return node, nil
+	}
+	return nil, ErrNotFound
+}
+
+// Find the last node with a value less than or equal to
+// the given value like SearchDesc. The error tells whether
+// the skiplist is empty, ErrEmpty, or every value is
+// greater than the given value, ErrNotFound.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) FindLE(value T) (*Node[T], error) {
+	if l.length == 0 {
+		return nil, ErrEmpty
+	}
+	if node := l.SearchDesc(value); node != nil {
+		return node, nil
+	}
+	return nil, ErrNotFound
+}
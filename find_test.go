@@ -0,0 +1,41 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{
+		nil,
+		{skiplist.WithBloomFilter(func(v int) uint64 { return uint64(v) * 0x9e3779b97f4a7c15 })},
+	} {
+		sl := skiplist.New(less[int], opts...)
+		for _, find := range []func(int) (*skiplist.Node[int], error){sl.Find, sl.FindGE, sl.FindLE} {
+			node, err := find(1)
+			require.Nil(t, node)
+			require.ErrorIs(t, err, skiplist.ErrEmpty)
+		}
+		addAll(t, sl, []int{2, 4, 6})
+
+		node, err := sl.Find(4)
+		require.NoError(t, err)
+		require.Equal(t, 4, node.Value())
+		_, err = sl.Find(3)
+		require.ErrorIs(t, err, skiplist.ErrNotFound)
+
+		node, err = sl.FindGE(3)
+		require.NoError(t, err)
+		require.Equal(t, 4, node.Value())
+		_, err = sl.FindGE(7)
+		require.ErrorIs(t, err, skiplist.ErrNotFound)
+
+		node, err = sl.FindLE(3)
+		require.NoError(t, err)
+		require.Equal(t, 2, node.Value())
+		_, err = sl.FindLE(1)
+		require.ErrorIs(t, err, skiplist.ErrNotFound)
+	}
+}
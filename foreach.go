@@ -0,0 +1,53 @@
+package skiplist
+
+// An Action tells ForEachSafe how to proceed
+// after visiting a node.
+type Action int
+
+const (
+	// Continue to the next node.
+	Continue Action = iota
+	// Remove the visited node and continue
+	// to the next node.
+	Remove
+	// Stop the iteration.
+	Stop
+)
+
+// Call fn for every node in ascending order. The action
+// returned by fn decides whether the visited node is
+// removed and whether the iteration should continue.
+// Nodes must not be added or removed by fn, return
+// Remove to remove the visited node.
+// Complexity: O(n)
+func (l *SkipList[T]) ForEachSafe(fn func(node *Node[T]) Action) {
+	for node := l.head.lanes[0]; node != l.tail; {
+		next := node.lanes[0]
+		l.visit()
+		switch fn(node) {
+		case Stop:
+			return
+		case Remove:
+			l.recordNode("unlink", node)
+			l.unlink(node)
+		}
+		node = next
+	}
+}
+
+// Remove every value in ascending order, calling fn with
+// each removed value. WithCounts, fn is called once for
+// every occurrence of a value.
+// The skiplist is empty when Drain returns, values added
+// by fn are drained as well.
+// Returns the number of values removed.
+// Complexity: O(n)
+func (l *SkipList[T]) Drain(fn func(value T)) int {
+	n := 0
+	for node := l.RemoveFirst(); node != nil; node = l.RemoveFirst() {
+		l.visit()
+		fn(node.value)
+		n++
+	}
+	return n
+}
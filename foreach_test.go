@@ -0,0 +1,85 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachSafe(t *testing.T) {
+	const numElem = 1 << 12
+	sortedData := [numElem]int{}
+	for i := 0; i < numElem; i++ {
+		sortedData[i] = i
+	}
+	t.Run("RemoveOdd", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, sortedData[:])
+		visited := 0
+		sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+			require.Equal(t, sortedData[visited], node.Value())
+			visited++
+			if node.Value()%2 == 1 {
+				return skiplist.Remove
+			}
+			return skiplist.Continue
+		})
+		require.Equal(t, numElem, visited)
+		expectedData := []int{}
+		for i := range sortedData {
+			if sortedData[i]%2 == 0 {
+				expectedData = append(expectedData, sortedData[i])
+			}
+		}
+		requireEqual(t, sl, expectedData)
+		for i := range expectedData {
+			require.NotNil(t, sl.Search(expectedData[i]))
+			require.Equal(t, expectedData[i], sl.Search(expectedData[i]).Value())
+		}
+	})
+	t.Run("RemoveAll", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, sortedData[:])
+		sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+			return skiplist.Remove
+		})
+		requireEqual(t, sl, []int{})
+		addAll(t, sl, sortedData[:])
+		requireEqual(t, sl, sortedData[:])
+	})
+	t.Run("Stop", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, sortedData[:])
+		visited := 0
+		sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+			visited++
+			if node.Value() == 10 {
+				return skiplist.Stop
+			}
+			return skiplist.Remove
+		})
+		require.Equal(t, 11, visited)
+		requireEqual(t, sl, sortedData[10:])
+	})
+}
+
+func TestDrain(t *testing.T) {
+	sl := skiplist.New(less[int])
+	addAll(t, sl, []int{3, 1, 2})
+	var drained []int
+	require.Equal(t, 3, sl.Drain(func(value int) {
+		drained = append(drained, value)
+	}))
+	require.Equal(t, []int{1, 2, 3}, drained)
+	requireEqual(t, sl, []int{})
+
+	sl = skiplist.New(less[int], skiplist.WithCounts())
+	addAll(t, sl, []int{2, 1, 2})
+	drained = nil
+	require.Equal(t, 3, sl.Drain(func(value int) {
+		drained = append(drained, value)
+	}))
+	require.Equal(t, []int{1, 2, 2}, drained)
+	require.Equal(t, 0, sl.Length())
+}
@@ -0,0 +1,102 @@
+// Package gen generates keys with the distributions met in
+// practice, for benchmarking skiplists and tuning their
+// options. Uniformly random keys are rarely representative
+// and the distribution changes the performance of a
+// skiplist considerably.
+//
+// Every generator is deterministic for a given seed and
+// returns a function with the signature of an iter.Seq[int].
+// The generators panic on invalid arguments.
+package gen
+
+import (
+	"math/rand"
+)
+
+// Generate n keys drawn uniformly from [0, max).
+// Panics if max is less than 1.
+func Uniform(seed int64, n int, max int) func(yield func(int) bool) {
+	if max < 1 {
+		panic("gen: Uniform max must be positive")
+	}
+	return func(yield func(int) bool) {
+		rng := rand.New(rand.NewSource(seed))
+		for i := 0; i < n; i++ {
+			if !yield(rng.Intn(max)) {
+				return
+			}
+		}
+	}
+}
+
+// Generate n keys in [0, max) following a Zipf
+// distribution with exponent s > 1, where key k is drawn
+// with a probability proportional to 1/(k+1)^s. A few low
+// keys make up most of the keys, like the hot keys of a
+// cache.
+// Panics if s is not greater than 1 or max is less than 1.
+func Zipfian(seed int64, n int, s float64, max int) func(yield func(int) bool) {
+	if !(s > 1) {
+		panic("gen: Zipfian exponent must be greater than 1")
+	}
+	if max < 1 {
+		panic("gen: Zipfian max must be positive")
+	}
+	return func(yield func(int) bool) {
+		rng := rand.New(rand.NewSource(seed))
+		zipf := rand.NewZipf(rng, s, 1, uint64(max-1))
+		for i := 0; i < n; i++ {
+			if !yield(int(zipf.Uint64())) {
+				return
+			}
+		}
+	}
+}
+
+// Generate the keys 0 to n-1 in ascending order, each
+// offset by a random amount in [-jitter, jitter], like
+// timestamps arriving slightly out of order.
+// Panics if jitter is negative.
+func Sequential(seed int64, n int, jitter int) func(yield func(int) bool) {
+	if jitter < 0 {
+		panic("gen: Sequential jitter must not be negative")
+	}
+	return func(yield func(int) bool) {
+		rng := rand.New(rand.NewSource(seed))
+		for i := 0; i < n; i++ {
+			if !yield(i + rng.Intn(2*jitter+1) - jitter) {
+				return
+			}
+		}
+	}
+}
+
+// Generate the keys 0 to n-1 in ascending order except
+// that about the given fraction of them are swapped with
+// a key at a random position. Nearly sorted keys defeat
+// optimizations for sorted input, such as appending, with
+// the occasional key that must be searched for.
+// Panics if n is negative.
+func NearSorted(seed int64, n int, disorder float64) func(yield func(int) bool) {
+	if n < 0 {
+		panic("gen: NearSorted n must not be negative")
+	}
+	return func(yield func(int) bool) {
+		rng := rand.New(rand.NewSource(seed))
+		keys := make([]int, n)
+		for i := range keys {
+			keys[i] = i
+		}
+		for i := range keys {
+			if rng.Float64() < disorder {
+				j := rng.Intn(n)
+				keys[i], keys[j] = keys[j], keys[i]
+			}
+		}
+		for _, key := range keys {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
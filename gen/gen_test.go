@@ -0,0 +1,80 @@
+package gen_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/adriansahlman/skiplist/gen"
+	"github.com/stretchr/testify/require"
+)
+
+func collect(seq func(yield func(int) bool)) []int {
+	var keys []int
+	seq(func(key int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func TestGenerators(t *testing.T) {
+	t.Run("Uniform", func(t *testing.T) {
+		keys := collect(gen.Uniform(1, 1000, 10))
+		require.Len(t, keys, 1000)
+		for _, key := range keys {
+			require.True(t, key >= 0 && key < 10)
+		}
+		require.Equal(t, keys, collect(gen.Uniform(1, 1000, 10)))
+	})
+	t.Run("Zipfian", func(t *testing.T) {
+		keys := collect(gen.Zipfian(1, 1000, 2, 100))
+		require.Len(t, keys, 1000)
+		zeros := 0
+		for _, key := range keys {
+			require.True(t, key >= 0 && key < 100)
+			if key == 0 {
+				zeros++
+			}
+		}
+		// 1/ζ(2) ≈ 61% of the keys are 0
+		require.Greater(t, zeros, 500)
+	})
+	t.Run("Sequential", func(t *testing.T) {
+		keys := collect(gen.Sequential(1, 1000, 3))
+		require.Len(t, keys, 1000)
+		for i, key := range keys {
+			require.True(t, key >= i-3 && key <= i+3)
+		}
+	})
+	t.Run("NearSorted", func(t *testing.T) {
+		keys := collect(gen.NearSorted(1, 1000, 0.01))
+		require.False(t, sort.IntsAreSorted(keys))
+		sorted := append([]int(nil), keys...)
+		sort.Ints(sorted)
+		for i, key := range sorted {
+			require.Equal(t, i, key)
+		}
+		misplaced := 0
+		for i, key := range keys {
+			if i != key {
+				misplaced++
+			}
+		}
+		require.Less(t, misplaced, 50)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		require.PanicsWithValue(t, "gen: Uniform max must be positive", func() { gen.Uniform(1, 10, 0) })
+		require.PanicsWithValue(t, "gen: Zipfian exponent must be greater than 1", func() { gen.Zipfian(1, 10, 1, 10) })
+		require.PanicsWithValue(t, "gen: Zipfian max must be positive", func() { gen.Zipfian(1, 10, 2, 0) })
+		require.PanicsWithValue(t, "gen: Sequential jitter must not be negative", func() { gen.Sequential(1, 10, -1) })
+		require.PanicsWithValue(t, "gen: NearSorted n must not be negative", func() { gen.NearSorted(1, -1, 0.1) })
+	})
+	t.Run("Break", func(t *testing.T) {
+		count := 0
+		gen.Uniform(1, 1000, 10)(func(int) bool {
+			count++
+			return count < 5
+		})
+		require.Equal(t, 5, count)
+	})
+}
@@ -0,0 +1,154 @@
+package skiplist
+
+// Walk the skiplist once in ascending order and call fn
+// for every maximal run of consecutive values where
+// sameGroup reports true for each pair of neighbouring
+// values (sameGroup(prev, next)).
+// The group slice is reused between calls and must not
+// be retained after fn returns.
+// Complexity: O(n)
+func (l *SkipList[T]) GroupRuns(
+	sameGroup func(a, b T) bool,
+	fn func(group []T),
+) {
+	var group []T
+	for node := l.First(); node != nil; node = node.Next() {
+		if len(group) > 0 && !sameGroup(group[len(group)-1], node.value) {
+			fn(group)
+			group = group[:0]
+		}
+		group = append(group, node.value)
+	}
+	if len(group) > 0 {
+		fn(group)
+	}
+}
+
+// Find two distinct nodes x and y, with x before y, for
+// which pred returns 0, by moving inward from both ends
+// of the skiplist. pred must return a negative number if
+// the value of x should be greater, and a positive number
+// if the value of y should be less, such as comparing the
+// sum of the values with a target sum.
+// Returns nil nodes if no such pair is found.
+// Complexity: O(n)
+func (l *SkipList[T]) FindPair(pred func(a, b T) int) (x, y *Node[T]) {
+	x, y = l.head.lanes[0], l.tail.prevs[0]
+	for x != y && x != l.tail && y != l.head {
+		switch c := pred(x.value, y.value); {
+		case c == 0:
+			return x, y
+		case c < 0:
+			x = x.lanes[0]
+		default:
+			y = y.prevs[0]
+		}
+	}
+	return nil, nil
+}
+
+// Find the longest run of consecutive nodes where adjacent
+// reports true for each pair of neighbouring values. The
+// first of equally long runs is returned.
+// Returns a nil node and a length of 0 if the skiplist
+// is empty.
+// Complexity: O(n)
+func (l *SkipList[T]) LongestRun(
+	adjacent func(prev, next T) bool,
+) (start *Node[T], length int) {
+	var runStart *Node[T]
+	runLength := 0
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		if runLength > 0 && adjacent(node.prevs[0].value, node.value) {
+			runLength++
+		} else {
+			runStart, runLength = node, 1
+		}
+		if runLength > length {
+			start, length = runStart, runLength
+		}
+	}
+	return start, length
+}
+
+// Get a sequence of the values missing between
+// neighbouring values, in ascending order, where step
+// gives the value expected to follow a value. Values
+// from step(prev) up to but excluding the next value
+// in the skiplist are missing. The value returned by
+// step must be greater than prev, the sequence panics
+// otherwise. The sequence has the signature of an
+// iter.Seq[T].
+// Complexity: O(n + m) for m missing values
+func (l *SkipList[T]) Gaps(step func(prev T) T) func(yield func(T) bool) {
+	advance := func(prev T) T {
+		next := step(prev)
+		if !l.less(prev, next) {
+			panic("skiplist: Gaps step did not advance past the previous value")
+		}
+		return next
+	}
+	return func(yield func(T) bool) {
+		for node := l.head.lanes[0]; node != l.tail && node.lanes[0] != l.tail; node = node.lanes[0] {
+			next := node.lanes[0].value
+			for missing := advance(node.value); l.less(missing, next); missing = advance(missing) {
+				if !yield(missing) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// A contiguous range of nodes of a skiplist.
+type Range[T any] struct {
+	// The first and last node of the range.
+	First, Last *Node[T]
+	// The total weight of the values of the range.
+	Weight float64
+}
+
+// Split the skiplist into at most the given number of
+// contiguous ranges with approximately equal total weight
+// of their values, for distributing work when the cost of
+// processing values varies. A range is closed once the
+// total weight up to and including it reaches its share
+// of the total weight. No range is empty.
+// Complexity: O(n)
+func (l *SkipList[T]) PartitionByWeight(
+	weight func(T) float64,
+	parts int,
+) []Range[T] {
+	if l.length == 0 {
+		return nil
+	}
+	if parts < 1 {
+		parts = 1
+	}
+	weights := make([]float64, 0, l.length)
+	total := 0.0
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		w := weight(node.value)
+		weights = append(weights, w)
+		total += w
+	}
+	ranges := make([]Range[T], 0, parts)
+	r := Range[T]{}
+	sum := 0.0
+	for i, node := 0, l.head.lanes[0]; node != l.tail; i, node = i+1, node.lanes[0] {
+		if r.First == nil {
+			r.First = node
+		}
+		r.Last = node
+		r.Weight += weights[i]
+		sum += weights[i]
+		if len(ranges) < parts-1 && sum >= total*float64(len(ranges)+1)/float64(parts) {
+			ranges = append(ranges, r)
+			r = Range[T]{}
+		}
+	}
+	if r.First != nil {
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
@@ -0,0 +1,128 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupRuns(t *testing.T) {
+	type kv struct{ key, value int }
+	lessKey := func(a, b kv) bool { return a.key < b.key }
+	sameKey := func(a, b kv) bool { return a.key == b.key }
+	sl := skiplist.New(lessKey)
+	sl.GroupRuns(sameKey, func(group []kv) {
+		t.Fatal("expected no groups for an empty skiplist")
+	})
+	for key := 0; key < 64; key++ {
+		for value := 0; value <= key%5; value++ {
+			sl.Add(kv{key: key, value: value})
+		}
+	}
+	sums := []int{}
+	sl.GroupRuns(sameKey, func(group []kv) {
+		require.NotEmpty(t, group)
+		sum := 0
+		for i := range group {
+			require.Equal(t, group[0].key, group[i].key)
+			sum += group[i].value
+		}
+		require.Equal(t, len(sums), group[0].key)
+		sums = append(sums, sum)
+	})
+	require.Len(t, sums, 64)
+	for key, sum := range sums {
+		n := key % 5
+		require.Equal(t, n*(n+1)/2, sum)
+	}
+}
+
+func TestFindPair(t *testing.T) {
+	sl := skiplist.New(less[int])
+	sumTo := func(target int) func(a, b int) int {
+		return func(a, b int) int { return a + b - target }
+	}
+	x, y := sl.FindPair(sumTo(0))
+	require.Nil(t, x)
+	require.Nil(t, y)
+	addAll(t, sl, []int{1, 4, 6, 9, 15})
+	x, y = sl.FindPair(sumTo(15))
+	require.Equal(t, 6, x.Value())
+	require.Equal(t, 9, y.Value())
+	x, y = sl.FindPair(sumTo(16))
+	require.Equal(t, 1, x.Value())
+	require.Equal(t, 15, y.Value())
+	// a node is not paired with itself.
+	x, y = sl.FindPair(sumTo(12))
+	require.Nil(t, x)
+	require.Nil(t, y)
+}
+
+func TestLongestRun(t *testing.T) {
+	sl := skiplist.New(less[int])
+	consecutive := func(prev, next int) bool { return next == prev+1 }
+	start, length := sl.LongestRun(consecutive)
+	require.Nil(t, start)
+	require.Equal(t, 0, length)
+	addAll(t, sl, []int{1, 2, 4, 5, 6, 8, 10, 11, 12})
+	start, length = sl.LongestRun(consecutive)
+	require.Equal(t, 4, start.Value())
+	require.Equal(t, 3, length)
+	start, length = sl.LongestRun(func(prev, next int) bool { return false })
+	require.Equal(t, 1, start.Value())
+	require.Equal(t, 1, length)
+}
+
+func TestGaps(t *testing.T) {
+	collect := func(seq func(yield func(int) bool)) []int {
+		values := []int{}
+		seq(func(v int) bool {
+			values = append(values, v)
+			return true
+		})
+		return values
+	}
+	increment := func(prev int) int { return prev + 1 }
+	sl := skiplist.New(less[int])
+	require.Empty(t, collect(sl.Gaps(increment)))
+	addAll(t, sl, []int{1, 2, 2, 5, 6, 8})
+	require.Equal(t, []int{3, 4, 7}, collect(sl.Gaps(increment)))
+	require.Equal(t, []int{4}, collect(sl.Gaps(func(prev int) int { return prev + 2 })))
+	n := 0
+	sl.Gaps(increment)(func(int) bool {
+		n++
+		return false
+	})
+	require.Equal(t, 1, n)
+	// a step that does not advance would never end.
+	require.Panics(t, func() {
+		collect(sl.Gaps(func(prev int) int { return prev }))
+	})
+}
+
+func TestPartitionByWeight(t *testing.T) {
+	sl := skiplist.New(less[int])
+	weight := func(v int) float64 { return float64(v) }
+	require.Empty(t, sl.PartitionByWeight(weight, 3))
+	addAll(t, sl, []int{1, 1, 1, 1, 2, 2, 4, 8})
+	ranges := sl.PartitionByWeight(weight, 2)
+	require.Len(t, ranges, 2)
+	require.Equal(t, 1, ranges[0].First.Value())
+	require.Equal(t, 4, ranges[0].Last.Value())
+	require.Equal(t, 12.0, ranges[0].Weight)
+	require.Equal(t, 8, ranges[1].First.Value())
+	require.Equal(t, 8.0, ranges[1].Weight)
+
+	// every node is in exactly one range.
+	for parts := 1; parts <= 10; parts++ {
+		ranges := sl.PartitionByWeight(weight, parts)
+		require.LessOrEqual(t, len(ranges), parts)
+		node := sl.First()
+		for _, r := range ranges {
+			require.Same(t, node, r.First)
+			node = r.Last.Next()
+		}
+		require.Nil(t, node)
+	}
+}
@@ -0,0 +1,61 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"io"
+)
+
+// Compute an order sensitive digest of the values of the
+// skiplist, so that two skiplists can be compared without
+// exchanging their values. Each value is written to h as
+// encoded by encode, prefixed with its encoded length, in
+// the order the skiplist is iterated: ascending, with equal
+// values in the reverse order of their insertion.
+// WithCounts the count of each value is written after it.
+// Returns the sum of h, which is not reset beforehand.
+// Complexity: O(n)
+func (l *SkipList[T]) Hash(h hash.Hash, encode func(w io.Writer, value T)) []byte {
+	l.hashNodes(h, encode, l.head.lanes[0], l.tail)
+	return h.Sum(nil)
+}
+
+// Write the framed values of the nodes from first up
+// to but excluding end to the hash.
+func (l *SkipList[T]) hashNodes(
+	h hash.Hash,
+	encode func(w io.Writer, value T),
+	first *Node[T],
+	end *Node[T],
+) {
+	var (
+		buf    bytes.Buffer
+		prefix [binary.MaxVarintLen64]byte
+	)
+	for node := first; node != end; node = node.lanes[0] {
+		buf.Reset()
+		encode(&buf, node.value)
+		h.Write(prefix[:binary.PutUvarint(prefix[:], uint64(buf.Len()))])
+		h.Write(buf.Bytes())
+		if l.counts {
+			h.Write(prefix[:binary.PutUvarint(prefix[:], uint64(node.Count()))])
+		}
+	}
+}
+
+// Compute a digest like Hash of only the values in the
+// range [min, max], so that two replicas can narrow down
+// the ranges in which they differ by comparing the digests
+// of ever smaller ranges.
+// Average complexity: O(log(n) + m) for m values in the range
+func (l *SkipList[T]) RangeHash(
+	h hash.Hash,
+	encode func(w io.Writer, value T),
+	min, max T,
+) []byte {
+	if first, last := l.RangeBounds(min, max); first != nil {
+		l.hashNodes(h, encode, first, last.lanes[0])
+	}
+	return h.Sum(nil)
+}
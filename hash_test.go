@@ -0,0 +1,62 @@
+package skiplist_test
+
+import (
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeString(w io.Writer, value string) {
+	_, _ = io.WriteString(w, value)
+}
+
+func TestHash(t *testing.T) {
+	a := skiplist.New(less[string])
+	b := skiplist.New(less[string])
+	require.Equal(t, a.Hash(sha256.New(), encodeString), b.Hash(sha256.New(), encodeString))
+	addAll(t, a, []string{"a", "bc", "d"})
+	addAll(t, b, []string{"d", "bc", "a"})
+	require.Equal(t, a.Hash(sha256.New(), encodeString), b.Hash(sha256.New(), encodeString))
+
+	// values are framed, so concatenations differ.
+	c := skiplist.New(less[string])
+	addAll(t, c, []string{"ab", "c", "d"})
+	require.NotEqual(t, a.Hash(sha256.New(), encodeString), c.Hash(sha256.New(), encodeString))
+
+	b.Remove("d")
+	require.NotEqual(t, a.Hash(sha256.New(), encodeString), b.Hash(sha256.New(), encodeString))
+
+	counted := skiplist.New(less[string], skiplist.WithCounts())
+	addAll(t, counted, []string{"a", "a"})
+	once := counted.Hash(sha256.New(), encodeString)
+	counted.Add("a")
+	require.NotEqual(t, once, counted.Hash(sha256.New(), encodeString))
+
+	// iteration order is ascending with equal values
+	// in the reverse order of their insertion.
+	lessKey := func(a, b [2]int) bool { return a[0] < b[0] }
+	sl := skiplist.New(lessKey)
+	addAll(t, sl, [][2]int{{2, 0}, {1, 0}, {2, 1}, {2, 2}})
+	require.Equal(t, [][2]int{{1, 0}, {2, 2}, {2, 1}, {2, 0}}, sl.FirstN(4))
+}
+
+func TestRangeHash(t *testing.T) {
+	a := skiplist.New(less[string])
+	b := skiplist.New(less[string])
+	addAll(t, a, []string{"a", "b", "c", "d", "e", "f"})
+	addAll(t, b, []string{"a", "b", "c", "x", "e", "f"})
+	rangeHash := func(sl *skiplist.SkipList[string], min, max string) []byte {
+		return sl.RangeHash(sha256.New(), encodeString, min, max)
+	}
+	require.Equal(t, rangeHash(a, "a", "c"), rangeHash(b, "a", "c"))
+	require.Equal(t, rangeHash(a, "e", "f"), rangeHash(b, "e", "f"))
+	require.NotEqual(t, rangeHash(a, "d", "e"), rangeHash(b, "d", "e"))
+	require.NotEqual(t, rangeHash(a, "d", "z"), rangeHash(b, "d", "z"))
+	// a range matching every value equals the full hash.
+	require.Equal(t, a.Hash(sha256.New(), encodeString), rangeHash(a, "a", "z"))
+	// empty ranges
+	require.Equal(t, rangeHash(a, "g", "h"), rangeHash(b, "y", "z"))
+}
@@ -0,0 +1,160 @@
+// Package hashring provides a consistent hashing ring that
+// keeps the tokens of its virtual nodes in a skiplist.
+//
+// Every node of the ring is given a number of tokens on
+// the ring of uint64 hashes, and a key hash is owned by the
+// node with the first token at or after the hash, wrapping
+// around to the first token. Adding or removing a node
+// moves only the keys owned by its tokens.
+package hashring
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+// A Ring is a consistent hashing ring of nodes identified
+// by values of type N. It is not threadsafe.
+type Ring[N comparable] struct {
+	list     *skiplist.SkipList[vnode[N]]
+	replicas int
+	token    func(node N, replica int) uint64
+	// The skiplist nodes of the tokens of each node.
+	vnodes map[N][]*skiplist.Node[vnode[N]]
+}
+
+// A virtual node, a token owned by a node.
+type vnode[N comparable] struct {
+	token uint64
+	node  N
+}
+
+// Create a ring where every node is given the number of
+// replicas of tokens, with token called for every replica
+// index of a node to get its token. Panics if replicas is
+// less than 1.
+func New[N comparable](
+	replicas int,
+	token func(node N, replica int) uint64,
+) *Ring[N] {
+	if replicas < 1 {
+		panic("hashring: replicas must be at least 1")
+	}
+	return &Ring[N]{
+		list: skiplist.New(func(a, b vnode[N]) bool {
+			return a.token < b.token
+		}),
+		replicas: replicas,
+		token:    token,
+		vnodes:   make(map[N][]*skiplist.Node[vnode[N]]),
+	}
+}
+
+// A token function for nodes identified by strings,
+// hashing the node and the replica index with FNV-1a.
+// The hash is mixed like the finalizer of SplitMix64,
+// as FNV-1a spreads similar inputs poorly.
+func StringToken(node string, replica int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(node))
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(replica))
+	h.Write(buf[:])
+	z := h.Sum64()
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Get the number of nodes in the ring.
+func (r *Ring[N]) Len() int {
+	return len(r.vnodes)
+}
+
+// Add a node and its tokens to the ring.
+// Returns false if the node is already in the ring.
+// Average complexity: O(r*log(n)) for r replicas
+func (r *Ring[N]) AddNode(node N) bool {
+	if _, ok := r.vnodes[node]; ok {
+		return false
+	}
+	vnodes := make([]*skiplist.Node[vnode[N]], r.replicas)
+	for replica := range vnodes {
+		vnodes[replica], _ = r.list.Add(vnode[N]{
+			token: r.token(node, replica),
+			node:  node,
+		})
+	}
+	r.vnodes[node] = vnodes
+	return true
+}
+
+// Remove a node and its tokens from the ring.
+// Returns false if the node is not in the ring.
+// Average complexity: O(r*log(n)) for r replicas
+func (r *Ring[N]) RemoveNode(node N) bool {
+	vnodes, ok := r.vnodes[node]
+	if !ok {
+		return false
+	}
+	for _, vnode := range vnodes {
+		vnode.RemoveFrom(r.list)
+	}
+	delete(r.vnodes, node)
+	return true
+}
+
+// Get the node owning the hash, the node with the first
+// token at or after the hash, wrapping around the ring.
+// Returns false if the ring is empty.
+// Average complexity: O(log(n))
+func (r *Ring[N]) Lookup(hash uint64) (node N, ok bool) {
+	vnode := r.successor(hash)
+	if vnode == nil {
+		return node, false
+	}
+	return vnode.Value().node, true
+}
+
+// Get up to n distinct nodes in the order their tokens
+// follow the hash around the ring, starting with the
+// owner of the hash, such as the nodes to replicate a key
+// to. Returns no nodes if n is less than 1.
+// Complexity: O(log(n) + t) for the t tokens visited
+func (r *Ring[N]) Successors(hash uint64, n int) []N {
+	if n > len(r.vnodes) {
+		n = len(r.vnodes)
+	}
+	if n < 1 {
+		return nil
+	}
+	nodes := make([]N, 0, n)
+	seen := make(map[N]bool, n)
+	for vnode := r.successor(hash); len(nodes) < n; vnode = r.next(vnode) {
+		if node := vnode.Value().node; !seen[node] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Get the first token at or after the hash, wrapping
+// around the ring. Returns nil if the ring is empty.
+func (r *Ring[N]) successor(hash uint64) *skiplist.Node[vnode[N]] {
+	if vnode := r.list.Search(vnode[N]{token: hash}); vnode != nil {
+		return vnode
+	}
+	return r.list.First()
+}
+
+// Get the token following the given token,
+// wrapping around the ring.
+func (r *Ring[N]) next(vnode *skiplist.Node[vnode[N]]) *skiplist.Node[vnode[N]] {
+	if next := vnode.Next(); next != nil {
+		return next
+	}
+	return r.list.First()
+}
@@ -0,0 +1,77 @@
+package hashring_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/adriansahlman/skiplist/hashring"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRing(t *testing.T) {
+	// tokens of node n are n*100 + replica*1000
+	r := hashring.New(3, func(node int, replica int) uint64 {
+		return uint64(node*100 + replica*1000)
+	})
+	_, ok := r.Lookup(0)
+	require.False(t, ok)
+	require.Empty(t, r.Successors(0, 2))
+	require.True(t, r.AddNode(1))
+	require.True(t, r.AddNode(2))
+	require.False(t, r.AddNode(1))
+	require.Equal(t, 2, r.Len())
+	for hash, want := range map[uint64]int{
+		0:    1,
+		100:  1,
+		101:  2,
+		1150: 2,
+		2201: 1,
+		9999: 1,
+	} {
+		node, ok := r.Lookup(hash)
+		require.True(t, ok)
+		require.Equal(t, want, node, "hash %d", hash)
+	}
+	require.Equal(t, []int{2, 1}, r.Successors(150, 5))
+	require.Equal(t, []int{2}, r.Successors(150, 1))
+	require.Empty(t, r.Successors(150, 0))
+	require.Empty(t, r.Successors(150, -1))
+
+	require.True(t, r.RemoveNode(1))
+	require.False(t, r.RemoveNode(1))
+	node, _ := r.Lookup(0)
+	require.Equal(t, 2, node)
+	require.True(t, r.RemoveNode(2))
+	_, ok = r.Lookup(0)
+	require.False(t, ok)
+}
+
+func TestRingBalance(t *testing.T) {
+	r := hashring.New(100, hashring.StringToken)
+	for i := 0; i < 10; i++ {
+		r.AddNode(fmt.Sprint("node", i))
+	}
+	rng := rand.New(rand.NewSource(0))
+	owners := map[string]string{}
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprint("key", i)
+		node, _ := r.Lookup(rng.Uint64())
+		owners[key] = node
+		counts[node]++
+	}
+	for _, count := range counts {
+		require.InDelta(t, 1000, count, 500)
+	}
+	// removing a node only moves the keys it owned
+	r.RemoveNode("node3")
+	rng = rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprint("key", i)
+		node, _ := r.Lookup(rng.Uint64())
+		if owners[key] != "node3" {
+			require.Equal(t, owners[key], node)
+		}
+	}
+}
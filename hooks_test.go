@@ -0,0 +1,25 @@
+package skiplist
+
+// Hooks into the internals of the package
+// for the tests of package skiplist_test.
+
+// Replace the function yielding the processor
+// WithYieldEvery until restore is called.
+func SetGosched(fn func()) (restore func()) {
+	prev := gosched
+	gosched = fn
+	return func() {
+		gosched = prev
+	}
+}
+
+// Replace the function reading the memory used by the
+// runtime and its limit for MemoryLimitPressure until
+// restore is called.
+func SetReadMemory(fn func() (used uint64, limit int64)) (restore func()) {
+	prev := readMemory
+	readMemory = fn
+	return func() {
+		readMemory = prev
+	}
+}
@@ -0,0 +1,71 @@
+package skiplist
+
+// A small cache of the nodes most recently found by
+// Search and Remove, consulted before descending.
+type hotCache[T any] struct {
+	nodes []*Node[T]
+	// Slot to store the next node in.
+	next int
+}
+
+// Get a cached node holding the first occurrence of a value
+// equal to the given value. A cached node is checked to
+// still hold such a value by comparing it and the value of
+// its predecessor, so nodes updated in place are never
+// returned by mistake.
+func (l *SkipList[T]) cached(value T) *Node[T] {
+	for _, node := range l.hot.nodes {
+		if node == nil || l.less(node.value, value) || l.less(value, node.value) {
+			continue
+		}
+		if prev := node.prevs[0]; prev == l.head || l.less(prev.value, value) {
+			return node
+		}
+	}
+	return nil
+}
+
+// Store a node found by a search in the cache, replacing
+// the node that was cached the longest.
+func (l *SkipList[T]) cache(node *Node[T]) {
+	l.hot.nodes[l.hot.next] = node
+	l.hot.next = (l.hot.next + 1) % len(l.hot.nodes)
+}
+
+// Drop an unlinked node from the cache.
+func (c *hotCache[T]) evict(node *Node[T]) {
+	for i := range c.nodes {
+		if c.nodes[i] == node {
+			c.nodes[i] = nil
+		}
+	}
+}
+
+// Drop every node from the cache.
+func (c *hotCache[T]) reset() {
+	for i := range c.nodes {
+		c.nodes[i] = nil
+	}
+}
+
+var _ Option = (*withHotCache)(nil)
+
+type withHotCache struct {
+	size int
+}
+
+func (o *withHotCache) apply(opts *options) {
+	opts.hotCache = o.size
+}
+
+// Cache the given number of nodes most recently found by
+// Search and Remove and check them before descending, which
+// speeds up workloads where a few hot values make up most
+// of the searches. Each cached node costs up to three
+// comparisons to check, so the cache should be small, such
+// as 4 or 8 nodes, and is only worth it when the values
+// searched for are heavily skewed.
+// Removed nodes are dropped from the cache.
+func WithHotCache(size int) Option {
+	return &withHotCache{size: size}
+}
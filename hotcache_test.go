@@ -0,0 +1,56 @@
+package skiplist_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotCache(t *testing.T) {
+	type entry struct {
+		key int
+		id  int
+	}
+	lessKey := func(a, b entry) bool { return a.key < b.key }
+	for _, opts := range [][]skiplist.Option{
+		{},
+		{skiplist.WithCounts()},
+		{skiplist.WithRanks()},
+	} {
+		rng := rand.New(rand.NewSource(0))
+		cached := skiplist.New(lessKey, append(opts, skiplist.WithHotCache(4))...)
+		plain := skiplist.New(lessKey, opts...)
+		for i := 0; i < 5000; i++ {
+			// mostly the hot keys 0 to 3
+			key := rng.Intn(4)
+			if rng.Intn(4) == 0 {
+				key = rng.Intn(100)
+			}
+			value := entry{key: key, id: i}
+			switch rng.Intn(3) {
+			case 0:
+				cached.Add(value)
+				plain.Add(value)
+			case 1:
+				got, want := cached.Search(value), plain.Search(value)
+				require.Equal(t, want == nil, got == nil)
+				if want != nil {
+					require.Equal(t, want.Value(), got.Value())
+					require.Equal(t, plain.IndexOf(want), cached.IndexOf(got))
+				}
+			case 2:
+				got, want := cached.Remove(value), plain.Remove(value)
+				require.Equal(t, want == nil, got == nil)
+				if want != nil {
+					require.Equal(t, want.Value(), got.Value())
+				}
+			}
+			require.Equal(t, plain.Length(), cached.Length())
+		}
+		require.NoError(t, cached.CheckStructure())
+		cached.Clear()
+		require.Nil(t, cached.Search(entry{key: 0}))
+	}
+}
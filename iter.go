@@ -0,0 +1,209 @@
+package skiplist
+
+// Position of an Iter that is not at a node.
+type iterPos int
+
+const (
+	// The iterator has not been positioned yet.
+	iterUnpositioned iterPos = iota
+	// The iterator is at a node.
+	iterValid
+	// The iterator has moved before the first
+	// node within its bounds.
+	iterBeforeFirst
+	// The iterator has moved after the last
+	// node within its bounds.
+	iterAfterLast
+)
+
+// An Iter moves over the nodes of a skiplist in either
+// direction while keeping within an optional lower and
+// upper bound. The lower bound is inclusive and the upper
+// bound is exclusive.
+//
+// A new or reset iterator is not positioned at any node.
+// Calling Next moves it to the first node within the
+// bounds and calling Prev moves it to the last node within
+// the bounds. Once the iterator has moved past either end,
+// it can be moved back in the opposite direction.
+//
+// If the skiplist was created WithFailFast, moving the
+// iterator after the skiplist has been modified fails
+// with ErrConcurrentModification. Repositioning the
+// iterator with SeekGE, SeekLT or Reset clears the error.
+type Iter[T any] struct {
+	list  *SkipList[T]
+	lower *T
+	upper *T
+	node  *Node[T]
+	pos   iterPos
+	// The generation of the skiplist when the
+	// iterator was last repositioned.
+	generation uint64
+	err        error
+}
+
+// Create an iterator over the nodes with values in the
+// range [lower, upper). A nil bound leaves the iterator
+// unbounded in that direction.
+func (l *SkipList[T]) NewIter(lower, upper *T) *Iter[T] {
+	return &Iter[T]{
+		list:       l,
+		lower:      lower,
+		upper:      upper,
+		generation: l.generation,
+	}
+}
+
+// Get the error that stopped the iterator, if any.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// Get the node the iterator is positioned at.
+// Returns nil if the iterator is not positioned
+// at a node.
+func (it *Iter[T]) Node() *Node[T] {
+	if it.pos != iterValid {
+		return nil
+	}
+	return it.node
+}
+
+// Move the iterator to the next node.
+// Returns false if there is no next node
+// within the bounds.
+// Average complexity: O(1), or O(log(n)) when
+// moving to the first node within the bounds.
+func (it *Iter[T]) Next() bool {
+	if !it.check() {
+		return false
+	}
+	it.list.visit()
+	switch it.pos {
+	case iterValid:
+		return it.setGE(it.node.Next())
+	case iterUnpositioned, iterBeforeFirst:
+		if it.lower != nil {
+			return it.setGE(it.list.Search(*it.lower))
+		}
+		return it.setGE(it.list.First())
+	}
+	return false
+}
+
+// Move the iterator to the previous node.
+// Returns false if there is no previous node
+// within the bounds.
+// Average complexity: O(1), or O(log(n)) when
+// moving to the last node within the bounds.
+func (it *Iter[T]) Prev() bool {
+	if !it.check() {
+		return false
+	}
+	it.list.visit()
+	switch it.pos {
+	case iterValid:
+		return it.setLT(it.node.Prev())
+	case iterUnpositioned, iterAfterLast:
+		if it.upper != nil {
+			return it.setLT(it.list.searchLT(*it.upper))
+		}
+		return it.setLT(it.list.Last())
+	}
+	return false
+}
+
+// Move the iterator to the first node with a value
+// greater than or equal to the given value, or to the
+// first node within the bounds if the value is less
+// than the lower bound.
+// Returns false if there is no such node.
+// Average complexity: O(log(n))
+func (it *Iter[T]) SeekGE(value T) bool {
+	it.reposition()
+	if it.lower != nil && it.list.less(value, *it.lower) {
+		value = *it.lower
+	}
+	return it.setGE(it.list.Search(value))
+}
+
+// Move the iterator to the last node with a value less
+// than the given value, or to the last node within the
+// bounds if the value is greater than the upper bound.
+// Returns false if there is no such node.
+// Average complexity: O(log(n))
+func (it *Iter[T]) SeekLT(value T) bool {
+	it.reposition()
+	if it.upper != nil && it.list.less(*it.upper, value) {
+		value = *it.upper
+	}
+	return it.setLT(it.list.searchLT(value))
+}
+
+// Reset the iterator so that it is no longer
+// positioned at any node.
+func (it *Iter[T]) Reset() {
+	it.reposition()
+	it.node = nil
+	it.pos = iterUnpositioned
+}
+
+// Stamp the iterator with the current generation
+// of the skiplist and clear any error.
+func (it *Iter[T]) reposition() {
+	it.generation = it.list.generation
+	it.err = nil
+}
+
+// Check that the skiplist has not been modified since
+// the iterator was repositioned. Unpositions the
+// iterator and records an error if it has.
+func (it *Iter[T]) check() bool {
+	if it.err == nil && it.list.failFast && it.generation != it.list.generation {
+		it.err = ErrConcurrentModification
+		it.node = nil
+		it.pos = iterUnpositioned
+	}
+	return it.err == nil
+}
+
+// Position the iterator at the node reached when moving
+// forward, unless it is beyond the upper bound.
+func (it *Iter[T]) setGE(node *Node[T]) bool {
+	if node == nil || it.upper != nil && !it.list.less(node.value, *it.upper) {
+		it.node = nil
+		it.pos = iterAfterLast
+		return false
+	}
+	it.node = node
+	it.pos = iterValid
+	return true
+}
+
+// Position the iterator at the node reached when moving
+// backward, unless it is beyond the lower bound.
+func (it *Iter[T]) setLT(node *Node[T]) bool {
+	if node == nil || it.lower != nil && it.list.less(node.value, *it.lower) {
+		it.node = nil
+		it.pos = iterBeforeFirst
+		return false
+	}
+	it.node = node
+	it.pos = iterValid
+	return true
+}
+
+// Find and return the last node with a value that
+// is less than the given value.
+// Returns nil if no such node exists.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) searchLT(value T) *Node[T] {
+	comparisons := 0
+	succ := l.tail
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		for ; succ.prevs[levelIdx] != l.head && !l.searchLess(&comparisons, succ.prevs[levelIdx].value, value); succ = succ.prevs[levelIdx] {
+		}
+	}
+	return succ.Prev()
+}
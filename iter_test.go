@@ -0,0 +1,135 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter(t *testing.T) {
+	const numElem = 1 << 10
+	sortedData := [numElem]int{}
+	for i := 0; i < numElem; i++ {
+		sortedData[i] = 2 * i
+	}
+	sl := skiplist.New(less[int])
+	addAll(t, sl, sortedData[:])
+	bound := func(v int) *int { return &v }
+	collect := func(it *skiplist.Iter[int], forward bool) []int {
+		values := []int{}
+		move := it.Next
+		if !forward {
+			move = it.Prev
+		}
+		for move() {
+			values = append(values, it.Node().Value())
+		}
+		require.Nil(t, it.Node())
+		return values
+	}
+	t.Run("Unbounded", func(t *testing.T) {
+		it := sl.NewIter(nil, nil)
+		require.Nil(t, it.Node())
+		require.Equal(t, sortedData[:], collect(it, true))
+		// moving back after exhausting the iterator
+		// starts from the last node.
+		values := collect(it, false)
+		require.Len(t, values, numElem)
+		for i := range values {
+			require.Equal(t, sortedData[numElem-1-i], values[i])
+		}
+		require.False(t, it.Prev())
+		require.True(t, it.Next())
+		require.Equal(t, sortedData[0], it.Node().Value())
+	})
+	t.Run("Bounded", func(t *testing.T) {
+		// bounds that fall both on and between values
+		for _, bounds := range [][2]int{{100, 200}, {101, 199}, {-10, 50}, {2000, 5000}} {
+			expected := []int{}
+			for _, v := range sortedData {
+				if v >= bounds[0] && v < bounds[1] {
+					expected = append(expected, v)
+				}
+			}
+			it := sl.NewIter(bound(bounds[0]), bound(bounds[1]))
+			require.Equal(t, expected, collect(it, true))
+			it.Reset()
+			values := collect(it, false)
+			require.Len(t, values, len(expected))
+			for i := range values {
+				require.Equal(t, expected[len(expected)-1-i], values[i])
+			}
+		}
+	})
+	t.Run("Seek", func(t *testing.T) {
+		it := sl.NewIter(bound(100), bound(200))
+		require.True(t, it.SeekGE(150))
+		require.Equal(t, 150, it.Node().Value())
+		require.True(t, it.SeekGE(151))
+		require.Equal(t, 152, it.Node().Value())
+		require.True(t, it.SeekGE(0))
+		require.Equal(t, 100, it.Node().Value())
+		require.False(t, it.Prev())
+		require.True(t, it.Next())
+		require.Equal(t, 100, it.Node().Value())
+		require.False(t, it.SeekGE(200))
+		require.Nil(t, it.Node())
+		require.True(t, it.SeekLT(150))
+		require.Equal(t, 148, it.Node().Value())
+		require.True(t, it.SeekLT(151))
+		require.Equal(t, 150, it.Node().Value())
+		require.True(t, it.SeekLT(1000))
+		require.Equal(t, 198, it.Node().Value())
+		require.False(t, it.Next())
+		require.True(t, it.Prev())
+		require.Equal(t, 198, it.Node().Value())
+		require.False(t, it.SeekLT(100))
+		require.True(t, it.Next())
+		require.Equal(t, 100, it.Node().Value())
+	})
+}
+
+func TestIterFailFast(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithFailFast())
+	addAll(t, sl, []int{0, 1, 2, 3})
+	it := sl.NewIter(nil, nil)
+	require.True(t, it.Next())
+	require.NoError(t, it.Err())
+	sl.Add(4)
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), skiplist.ErrConcurrentModification)
+	require.Nil(t, it.Node())
+	require.False(t, it.Prev())
+	require.True(t, it.SeekGE(2))
+	require.NoError(t, it.Err())
+	require.Equal(t, 2, it.Node().Value())
+	require.NotNil(t, sl.Remove(0))
+	require.False(t, it.Prev())
+	require.ErrorIs(t, it.Err(), skiplist.ErrConcurrentModification)
+	it.Reset()
+	require.NoError(t, it.Err())
+	require.True(t, it.Prev())
+	require.Equal(t, 4, it.Node().Value())
+	t.Run("Scanner", func(t *testing.T) {
+		s := sl.Scan()
+		require.True(t, s.Next())
+		require.NotNil(t, s.Node().RemoveFrom(sl))
+		require.False(t, s.Next())
+		require.ErrorIs(t, s.Err(), skiplist.ErrConcurrentModification)
+		s = sl.ResumeScan(s.Checkpoint())
+		require.True(t, s.Next())
+		require.NoError(t, s.Err())
+		require.Equal(t, 2, s.Node().Value())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, []int{0, 1, 2, 3})
+		it := sl.NewIter(nil, nil)
+		require.True(t, it.Next())
+		sl.Add(4)
+		require.True(t, it.Next())
+		require.NoError(t, it.Err())
+		require.Equal(t, 1, it.Node().Value())
+	})
+}
@@ -0,0 +1,36 @@
+package skiplist
+
+var _ Option = (*withHardLimit)(nil)
+
+type withHardLimit struct {
+	limit int
+}
+
+func (o *withHardLimit) apply(opts *options) {
+	opts.hardLimit = o.limit
+}
+
+// Limit the number of nodes in the skiplist. Values that
+// would add a node beyond the limit are rejected instead,
+// Add returns a nil node and TryAdd returns ErrFull.
+// Values replacing or counted by an existing node are
+// still accepted WithReplace and WithCounts.
+// A limit less than 1 disables the limit.
+func WithHardLimit(n int) Option {
+	return &withHardLimit{
+		limit: n,
+	}
+}
+
+// Check if inserting the value directly after the
+// given predecessors would exceed the hard limit.
+func (l *SkipList[T]) full(
+	value T,
+	preds *[MaxLevel]*Node[T],
+) bool {
+	if l.hardLimit <= 0 || l.length < l.hardLimit {
+		return false
+	}
+	// a replaced node makes room for the new node.
+	return !l.replace || l.equalSucc(value, preds) == nil
+}
@@ -0,0 +1,56 @@
+package skiplist_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardLimit(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithHardLimit(3))
+	addAll(t, sl, []int{1, 2, 3})
+	node, replacedNode := sl.Add(4)
+	require.Nil(t, node)
+	require.Nil(t, replacedNode)
+	_, _, err := sl.TryAdd(0)
+	require.ErrorIs(t, err, skiplist.ErrFull)
+	require.Nil(t, sl.AddNode(sl.NewNode(5, 1)))
+	requireEqual(t, sl, []int{1, 2, 3})
+
+	sl.RemoveFirst()
+	_, _, err = sl.TryAdd(0)
+	require.NoError(t, err)
+	requireEqual(t, sl, []int{0, 2, 3})
+
+	t.Run("Replace", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithHardLimit(2), skiplist.WithReplace())
+		addAll(t, sl, []int{1, 2, 2})
+		node, replacedNode := sl.Add(1)
+		require.NotNil(t, node)
+		require.NotNil(t, replacedNode)
+		node, _ = sl.Add(3)
+		require.Nil(t, node)
+		require.Equal(t, 2, sl.Length())
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithHardLimit(1), skiplist.WithCounts())
+		addAll(t, sl, []int{1, 1, 1})
+		require.Equal(t, 3, sl.CountOf(1))
+		node, _ := sl.Add(2)
+		require.Nil(t, node)
+	})
+
+	t.Run("ReadCSV", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithHardLimit(2))
+		_, err := sl.ReadCSV(
+			strings.NewReader("1\n2\n3\n"),
+			func(record []string) (int, error) { return strconv.Atoi(record[0]) },
+		)
+		require.ErrorIs(t, err, skiplist.ErrFull)
+		require.Equal(t, 2, sl.Length())
+	})
+}
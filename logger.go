@@ -0,0 +1,55 @@
+package skiplist
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ Option = (*withLogger)(nil)
+
+type withLogger struct {
+	logger *slog.Logger
+}
+
+func (o *withLogger) apply(opts *options) {
+	opts.logger = o.logger
+}
+
+// Log anomalies detected by the skiplist, such as removing
+// a node from a skiplist it is not part of or a comparator
+// that panics in a Try method, at warning level.
+// When the logger is enabled for debug level the comparator
+// is also checked for inconsistencies on every insertion,
+// at the cost of an additional comparison.
+func WithLogger(logger *slog.Logger) Option {
+	return &withLogger{
+		logger: logger,
+	}
+}
+
+// Log a warning if a logger is configured.
+func (l *SkipList[T]) warn(msg string, args ...any) {
+	if l.logger != nil {
+		l.logger.Warn(msg, args...)
+	}
+}
+
+// Log a warning if the comparator reports the value as
+// less than its predecessor, which it has already been
+// reported as greater than. Only checked when the logger
+// is enabled for debug level.
+func (l *SkipList[T]) checkOrder(
+	value T,
+	preds *[MaxLevel]*Node[T],
+) {
+	if l.logger == nil || !l.logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	if pred := preds[0]; pred != l.head && l.less(value, pred.value) {
+		l.warn(
+			"skiplist: inconsistent comparator",
+			slog.Any("value", value),
+			slog.Any("pred", pred.value),
+		)
+	}
+}
@@ -0,0 +1,53 @@
+package skiplist_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	sl := skiplist.New(less[int], skiplist.WithLogger(logger))
+	other := skiplist.New(less[int])
+	addAll(t, sl, []int{1, 2, 3})
+	require.Empty(t, buf.String())
+
+	node, _ := other.Add(2)
+	require.Nil(t, node.RemoveFrom(sl))
+	require.Contains(t, buf.String(), "removed node not found")
+	require.Equal(t, 3, sl.Length())
+
+	buf.Reset()
+	_, _, err := sl.TryAdd(-1)
+	require.NoError(t, err)
+	require.Empty(t, buf.String())
+
+	buf.Reset()
+	broken := skiplist.New(
+		func(a, b int) bool {
+			if a == 10 || b == 10 {
+				return true
+			}
+			return a < b
+		},
+		skiplist.WithLogger(logger),
+	)
+	addAll(t, broken, []int{1, 20})
+	broken.Add(10)
+	require.Contains(t, buf.String(), "inconsistent comparator")
+
+	buf.Reset()
+	panicking := skiplist.New(
+		func(a, b int) bool { panic("boom") },
+		skiplist.WithLogger(logger),
+	)
+	panicking.Add(1)
+	_, _, err = panicking.TryAdd(2)
+	require.Error(t, err)
+	require.Contains(t, buf.String(), "recovered from panic")
+}
@@ -0,0 +1,122 @@
+package skiplist
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A task run periodically by a Maintainer,
+// such as func() { list.Rebuild() }.
+type MaintenanceTask struct {
+	// The time between the end of a run of the
+	// task and the start of its next run.
+	Interval time.Duration
+	Run      func()
+}
+
+// A Maintainer runs maintenance tasks of skiplists
+// periodically in a background goroutine. As skiplists
+// are not threadsafe, every task is run while holding a
+// lock that must also guard all other use of the skiplists.
+//
+// Tasks are run one at a time. A task that takes longer
+// than the interval of other tasks delays them rather than
+// letting runs pile up, and the interval of a task starts
+// when its run ends, so a slow task is not run back to
+// back. Intervals are randomized by the jitter to spread
+// out the runs of maintainers started at the same time.
+type Maintainer struct {
+	mu     sync.Locker
+	jitter float64
+	tasks  []MaintenanceTask
+	rng    *rand.Rand
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// Create a maintainer of the given tasks that holds mu
+// while running a task. Every interval is randomly
+// lengthened or shortened by up to the jitter, given
+// as a fraction of the interval.
+func NewMaintainer(mu sync.Locker, jitter float64, tasks ...MaintenanceTask) *Maintainer {
+	return &Maintainer{
+		mu:     mu,
+		jitter: jitter,
+		tasks:  tasks,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Start running the tasks in a background goroutine.
+// Panics if the maintainer is already running.
+func (m *Maintainer) Start() {
+	if m.stop != nil {
+		panic("skiplist: maintainer already started")
+	}
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go m.run(m.stop, m.done)
+}
+
+// Stop running tasks and wait for a running task to
+// finish. The maintainer can be started again.
+// Must not be called while holding the lock of the
+// maintainer.
+func (m *Maintainer) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+	m.stop, m.done = nil, nil
+}
+
+// Run tasks as they become due until stopped.
+func (m *Maintainer) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	due := make([]time.Time, len(m.tasks))
+	now := time.Now()
+	for i := range due {
+		due[i] = now.Add(m.interval(i))
+	}
+	if len(due) == 0 {
+		<-stop
+		return
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		next := 0
+		for i := range due {
+			if due[i].Before(due[next]) {
+				next = i
+			}
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(time.Until(due[next]))
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+		m.mu.Lock()
+		m.tasks[next].Run()
+		m.mu.Unlock()
+		due[next] = time.Now().Add(m.interval(next))
+	}
+}
+
+// Get the interval of a task randomized by the jitter.
+func (m *Maintainer) interval(task int) time.Duration {
+	interval := m.tasks[task].Interval
+	if m.jitter > 0 {
+		interval += time.Duration((2*m.rng.Float64() - 1) * m.jitter * float64(interval))
+	}
+	return interval
+}
@@ -0,0 +1,59 @@
+package skiplist_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintainer(t *testing.T) {
+	var mu sync.Mutex
+	sl := skiplist.New(less[int])
+	rebuilds, trims := 0, 0
+	m := skiplist.NewMaintainer(&mu, 0.5,
+		skiplist.MaintenanceTask{
+			Interval: time.Millisecond,
+			Run: func() {
+				sl.Rebuild()
+				rebuilds++
+			},
+		},
+		skiplist.MaintenanceTask{
+			Interval: 2 * time.Millisecond,
+			Run: func() {
+				for sl.Length() > 10 {
+					sl.RemoveFirst()
+				}
+				trims++
+			},
+		},
+	)
+	m.Start()
+	require.Panics(t, m.Start)
+	for i := 0; i < 1000; i++ {
+		mu.Lock()
+		sl.Add(i)
+		mu.Unlock()
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return rebuilds >= 3 && trims >= 3 && sl.Length() <= 10
+	}, 5*time.Second, time.Millisecond)
+	m.Stop()
+	mu.Lock()
+	runs := rebuilds + trims
+	mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, runs, rebuilds+trims)
+	require.NoError(t, sl.CheckStructure())
+	m.Stop()
+
+	// a maintainer without tasks can be started and stopped.
+	empty := skiplist.NewMaintainer(&mu, 0)
+	empty.Start()
+	empty.Stop()
+}
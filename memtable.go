@@ -0,0 +1,72 @@
+package skiplist
+
+// A Memtable is a skiplist that keeps track of the
+// approximate encoded size of its values and signals
+// when a size limit has been reached, at which point the
+// skiplist can be rotated out and replaced by an empty one.
+type Memtable[T any] struct {
+	less  func(a, b T) bool
+	opts  []Option
+	size  func(T) int
+	limit int
+	list  *SkipList[T]
+	bytes int
+}
+
+// Create a memtable that is full once the sum of the
+// sizes of its values reaches the limit.
+// The options are applied to every skiplist created
+// by the memtable.
+func NewMemtable[T any](
+	less func(a, b T) bool,
+	size func(T) int,
+	limit int,
+	opts ...Option,
+) *Memtable[T] {
+	return &Memtable[T]{
+		less:  less,
+		opts:  opts,
+		size:  size,
+		limit: limit,
+		list:  New(less, opts...),
+	}
+}
+
+// Insert a value into the active skiplist.
+// Returns true if the memtable is full.
+// Average complexity: O(log(n))
+func (m *Memtable[T]) Add(value T) (full bool) {
+	_, replacedNode := m.list.Add(value)
+	m.bytes += m.size(value)
+	if replacedNode != nil {
+		m.bytes -= m.size(replacedNode.value)
+	}
+	return m.Full()
+}
+
+// Get the active skiplist.
+// It must not be modified other than through
+// the memtable.
+func (m *Memtable[T]) List() *SkipList[T] {
+	return m.list
+}
+
+// Get the sum of the sizes of all values
+// in the active skiplist.
+func (m *Memtable[T]) Size() int {
+	return m.bytes
+}
+
+// Check if the size limit has been reached.
+func (m *Memtable[T]) Full() bool {
+	return m.bytes >= m.limit
+}
+
+// Replace the active skiplist with an empty one
+// and return the previously active skiplist.
+func (m *Memtable[T]) Rotate() (frozen *SkipList[T]) {
+	frozen = m.list
+	m.list = New(m.less, m.opts...)
+	m.bytes = 0
+	return frozen
+}
@@ -0,0 +1,31 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemtable(t *testing.T) {
+	size := func(s string) int { return len(s) }
+	m := skiplist.NewMemtable(less[string], size, 16, skiplist.WithReplace())
+	require.False(t, m.Add("aaaa"))
+	require.False(t, m.Add("bbbbbbbb"))
+	require.Equal(t, 12, m.Size())
+	// replacing a value does not count twice
+	require.False(t, m.Add("aaaa"))
+	require.Equal(t, 12, m.Size())
+	require.True(t, m.Add("cccc"))
+	require.True(t, m.Full())
+	frozen := m.Rotate()
+	requireEqual(t, frozen, []string{"aaaa", "bbbbbbbb", "cccc"})
+	require.Equal(t, 0, m.Size())
+	require.False(t, m.Full())
+	require.Equal(t, 0, m.List().Length())
+	require.False(t, m.Add("dddd"))
+	requireEqual(t, m.List(), []string{"dddd"})
+	// options are applied to the new skiplists
+	m.Add("dddd")
+	requireEqual(t, m.List(), []string{"dddd"})
+}
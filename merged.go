@@ -0,0 +1,142 @@
+package skiplist
+
+// A MergedIter moves in ascending order over the nodes of
+// several skiplists sharing the same order, such as the
+// active skiplist of a Memtable and the skiplists that
+// were rotated out of it. Of the nodes with equal values
+// only the first node of the newest skiplist is visited,
+// so values in newer skiplists shadow older values.
+//
+// Like an Iter, a new merged iterator is not positioned
+// at any node until Next or SeekGE is called.
+type MergedIter[T any] struct {
+	// Iterators of the skiplists, newest first.
+	iters []*Iter[T]
+	node  *Node[T]
+	// At least one of the iterators has been positioned.
+	started bool
+}
+
+// Create an iterator over the nodes of the skiplists with
+// values in the range [lower, upper). The skiplists are
+// given from newest to oldest and must be created with
+// comparators defining the same order.
+func NewMergedIter[T any](lower, upper *T, lists ...*SkipList[T]) *MergedIter[T] {
+	iters := make([]*Iter[T], len(lists))
+	for i, l := range lists {
+		iters[i] = l.NewIter(lower, upper)
+	}
+	return &MergedIter[T]{
+		iters: iters,
+	}
+}
+
+// Get the error that stopped any of the underlying
+// iterators, if any.
+func (it *MergedIter[T]) Err() error {
+	for _, iter := range it.iters {
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the node the iterator is positioned at.
+// Returns nil if the iterator is not positioned
+// at a node.
+func (it *MergedIter[T]) Node() *Node[T] {
+	return it.node
+}
+
+// Move the iterator to the node with the next
+// distinct value.
+// Returns false if there is no such node.
+// Average complexity: O(k) for k skiplists, or
+// O(k*log(n)) when moving to the first node.
+func (it *MergedIter[T]) Next() bool {
+	if !it.started {
+		it.started = true
+		for _, iter := range it.iters {
+			iter.Next()
+		}
+		return it.pick()
+	}
+	if it.node == nil {
+		return false
+	}
+	// skip every node equal to the current value.
+	value := it.node.value
+	for _, iter := range it.iters {
+		for node := iter.Node(); node != nil && !iter.list.less(value, node.value); node = iter.Node() {
+			iter.Next()
+		}
+	}
+	return it.pick()
+}
+
+// Move the iterator to the node with the first value
+// greater than or equal to the given value.
+// Returns false if there is no such node.
+// Average complexity: O(k*log(n)) for k skiplists
+func (it *MergedIter[T]) SeekGE(value T) bool {
+	it.started = true
+	for _, iter := range it.iters {
+		iter.SeekGE(value)
+	}
+	return it.pick()
+}
+
+// Position the iterator at the least node of the
+// underlying iterators, preferring newer skiplists.
+func (it *MergedIter[T]) pick() bool {
+	it.node = nil
+	for _, iter := range it.iters {
+		node := iter.Node()
+		if node == nil {
+			continue
+		}
+		if it.node == nil || iter.list.less(node.value, it.node.value) {
+			it.node = node
+		}
+	}
+	return it.node != nil
+}
+
+// The side a value visited by MergeWith came from.
+type Source int
+
+const (
+	// The value is in the skiplist.
+	SourceList Source = iota
+	// The value was yielded by the sequence.
+	SourceSeq
+)
+
+// Walk the skiplist and a sequence of values in ascending
+// order together, calling fn with every value in order
+// along with the side it came from. Values of the skiplist
+// are visited before equal values of the sequence.
+// The sequence has the signature of an iter.Seq[T] and
+// must yield values in ascending order.
+// fn must not modify the skiplist.
+// Complexity: O(n + m) for m values of the sequence
+func (l *SkipList[T]) MergeWith(
+	seq func(yield func(T) bool),
+	fn func(src Source, value T),
+) {
+	node := l.head.lanes[0]
+	seq(func(value T) bool {
+		for ; node != l.tail && !l.less(value, node.value); node = node.lanes[0] {
+			l.visit()
+			fn(SourceList, node.value)
+		}
+		l.visit()
+		fn(SourceSeq, value)
+		return true
+	})
+	for ; node != l.tail; node = node.lanes[0] {
+		l.visit()
+		fn(SourceList, node.value)
+	}
+}
@@ -0,0 +1,77 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergedIter(t *testing.T) {
+	lessKey := func(a, b [2]int) bool { return a[0] < b[0] }
+	// the second element records which skiplist
+	// a value was added to.
+	oldest := skiplist.New(lessKey)
+	older := skiplist.New(lessKey)
+	active := skiplist.New(lessKey)
+	addAll(t, oldest, [][2]int{{1, 0}, {2, 0}, {3, 0}, {7, 0}})
+	addAll(t, older, [][2]int{{2, 1}, {4, 1}, {4, 1}, {7, 1}})
+	addAll(t, active, [][2]int{{3, 2}, {7, 2}, {8, 2}})
+	collect := func(it *skiplist.MergedIter[[2]int]) [][2]int {
+		values := [][2]int{}
+		for it.Next() {
+			values = append(values, it.Node().Value())
+		}
+		require.Nil(t, it.Node())
+		require.False(t, it.Next())
+		require.NoError(t, it.Err())
+		return values
+	}
+	it := skiplist.NewMergedIter(nil, nil, active, older, oldest)
+	require.Nil(t, it.Node())
+	require.Equal(t, [][2]int{{1, 0}, {2, 1}, {3, 2}, {4, 1}, {7, 2}, {8, 2}}, collect(it))
+
+	lower, upper := [2]int{2, 0}, [2]int{8, 0}
+	it = skiplist.NewMergedIter(&lower, &upper, active, older, oldest)
+	require.Equal(t, [][2]int{{2, 1}, {3, 2}, {4, 1}, {7, 2}}, collect(it))
+
+	require.True(t, it.SeekGE([2]int{5, 0}))
+	require.Equal(t, [2]int{7, 2}, it.Node().Value())
+	require.False(t, it.SeekGE([2]int{9, 0}))
+
+	it = skiplist.NewMergedIter[[2]int](nil, nil)
+	require.False(t, it.Next())
+}
+
+func TestMergeWith(t *testing.T) {
+	sl := skiplist.New(less[int])
+	addAll(t, sl, []int{1, 3, 3, 6})
+	seq := func(values ...int) func(yield func(int) bool) {
+		return func(yield func(int) bool) {
+			for _, v := range values {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	type visit struct {
+		src   skiplist.Source
+		value int
+	}
+	var visits []visit
+	sl.MergeWith(seq(0, 3, 4, 7, 8), func(src skiplist.Source, value int) {
+		visits = append(visits, visit{src, value})
+	})
+	list, other := skiplist.SourceList, skiplist.SourceSeq
+	require.Equal(t, []visit{
+		{other, 0}, {list, 1}, {list, 3}, {list, 3}, {other, 3},
+		{other, 4}, {list, 6}, {other, 7}, {other, 8},
+	}, visits)
+
+	visits = nil
+	sl.MergeWith(seq(), func(src skiplist.Source, value int) {
+		visits = append(visits, visit{src, value})
+	})
+	require.Equal(t, []visit{{list, 1}, {list, 3}, {list, 3}, {list, 6}}, visits)
+}
@@ -0,0 +1,83 @@
+package skiplist
+
+import "sort"
+
+// Find the first node with a value greater than or equal
+// to each of the given values, like calling Search for
+// every value, and return the nodes in the order of the
+// values. The values are searched for in ascending order
+// in a single pass, where each search continues from
+// where the previous one ended instead of descending from
+// the head, which is considerably faster than separate
+// searches for values near each other.
+// WithComparisonBudget, the budget applies to the search
+// for each value and not to sorting the values.
+// The given slice is not modified.
+// Average complexity: O(q*log(q) + q*log(n/q)) for q values
+func (l *SkipList[T]) MultiSearch(values []T) []*Node[T] {
+	nodes := make([]*Node[T], len(values))
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return l.less(values[order[a]], values[order[b]])
+	})
+	var preds [MaxLevel]*Node[T]
+	for levelIdx := range preds {
+		preds[levelIdx] = l.head
+	}
+	for _, idx := range order {
+		value := values[idx]
+		comparisons := 0
+		// the predecessors of the previous value precede
+		// the value as well, climb to the lowest level
+		// that does not need to move forward.
+		top := 0
+		for ; top < MaxLevel; top++ {
+			next := preds[top].lanes[top]
+			if next == l.tail || !l.searchLess(&comparisons, next.value, value) {
+				break
+			}
+		}
+		if top > 0 {
+			pred := preds[top-1]
+			for levelIdx := top - 1; levelIdx >= 0; levelIdx-- {
+				for ; pred.lanes[levelIdx] != l.tail && l.searchLess(&comparisons, pred.lanes[levelIdx].value, value); pred = pred.lanes[levelIdx] {
+				}
+				preds[levelIdx] = pred
+			}
+		}
+		nodes[idx] = preds[0].Next()
+	}
+	return nodes
+}
+
+// Check which of the given values the skiplist contains,
+// searching for them in a single pass like MultiSearch.
+// WithBloomFilter, most values that are not in the
+// skiplist are rejected without searching.
+// The given slice is not modified.
+// Average complexity: O(q*log(q) + q*log(n/q)) for q values
+func (l *SkipList[T]) MultiContains(values []T) []bool {
+	contains := make([]bool, len(values))
+	candidates := values
+	var idxs []int
+	if l.bloom != nil {
+		candidates = nil
+		for idx, value := range values {
+			if l.bloom.mayContain(value) {
+				candidates = append(candidates, value)
+				idxs = append(idxs, idx)
+			}
+		}
+	}
+	for i, node := range l.MultiSearch(candidates) {
+		idx := i
+		if idxs != nil {
+			idx = idxs[i]
+		}
+		contains[idx] = node != nil && !l.less(candidates[i], node.value)
+	}
+	return contains
+}
@@ -0,0 +1,65 @@
+package skiplist_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSearch(t *testing.T) {
+	sl := skiplist.New(less[int])
+	require.Equal(t, []*skiplist.Node[int]{nil, nil}, sl.MultiSearch([]int{1, 2}))
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		sl.Add(rng.Intn(2000))
+	}
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = rng.Intn(2100) - 50
+	}
+	values = append(values, values[0], 2500, -1)
+	query := append([]int(nil), values...)
+	nodes := sl.MultiSearch(query)
+	require.Equal(t, values, query)
+	require.Len(t, nodes, len(values))
+	for i, value := range values {
+		require.Same(t, sl.Search(value), nodes[i], "value %d", value)
+	}
+	require.Empty(t, sl.MultiSearch(nil))
+
+	t.Run("WithComparisonBudget", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithComparisonBudget(64))
+		for i := 0; i < 1000; i++ {
+			sl.Add(i)
+		}
+		// sorting the values takes far more
+		// comparisons than the budget.
+		values := rng.Perm(1000)
+		nodes := sl.MultiSearch(values)
+		for i, value := range values {
+			require.Equal(t, value, nodes[i].Value())
+		}
+	})
+}
+
+func TestMultiContains(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{
+		nil,
+		{skiplist.WithBloomFilter(func(v int) uint64 { return uint64(v) * 0x9e3779b97f4a7c15 })},
+	} {
+		sl := skiplist.New(less[int], opts...)
+		require.Equal(t, []bool{false}, sl.MultiContains([]int{1}))
+		for i := 0; i < 1000; i += 2 {
+			sl.Add(i)
+		}
+		values := []int{7, 8, -2, 998, 999, 0, 8, 500}
+		require.Equal(
+			t,
+			[]bool{false, true, false, true, false, true, true, true},
+			sl.MultiContains(values),
+		)
+		require.Empty(t, sl.MultiContains(nil))
+	}
+}
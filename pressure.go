@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"runtime/debug"
+	"runtime/metrics"
+	"sync/atomic"
+)
+
+// The number of calls between samples of the
+// memory of a MemoryLimitPressure callback.
+const pressureSampleInterval = 1024
+
+// Read the memory used by the Go runtime and
+// its memory limit, for MemoryLimitPressure.
+var readMemory = func() (used uint64, limit int64) {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+	used = samples[0].Value.Uint64() - samples[1].Value.Uint64()
+	// a negative limit reads the current limit.
+	return used, debug.SetMemoryLimit(-1)
+}
+
+var _ Option = (*withMemoryPressure)(nil)
+
+type withMemoryPressure struct {
+	pressured func() bool
+	factor    float64
+}
+
+func (o *withMemoryPressure) apply(opts *options) {
+	opts.pressured = o.pressured
+	opts.pressureFactor = o.factor
+}
+
+// Evict more aggressively while the process is under
+// memory pressure, as reported by pressured, by scaling
+// the limit of WithWeightLimit by the factor, such as 0.5
+// to halve the weight the skiplist may hold. Pressure is
+// checked after every insertion and has no effect without
+// WithWeightLimit. See MemoryLimitPressure for a callback
+// based on the memory limit of the Go runtime.
+func WithMemoryPressure(pressured func() bool, factor float64) Option {
+	return &withMemoryPressure{
+		pressured: pressured,
+		factor:    factor,
+	}
+}
+
+// Create a callback for WithMemoryPressure that reports
+// pressure while the memory mapped by the Go runtime, less
+// the memory returned to the operating system, exceeds the
+// given fraction of the memory limit set with
+// debug.SetMemoryLimit. This is the memory the runtime
+// compares with the limit. The memory is sampled every
+// 1024 calls and the last sample is reported in between.
+// The callback can be shared by skiplists used from
+// different goroutines.
+func MemoryLimitPressure(fraction float64) func() bool {
+	var (
+		calls     atomic.Uint64
+		pressured atomic.Bool
+	)
+	var mu atomic.Bool
+	return func() bool {
+		if calls.Add(1)%pressureSampleInterval != 1 || !mu.CompareAndSwap(false, true) {
+			return pressured.Load()
+		}
+		defer mu.Store(false)
+		used, limit := readMemory()
+		pressured.Store(float64(used) > fraction*float64(limit))
+		return pressured.Load()
+	}
+}
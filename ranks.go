@@ -0,0 +1,229 @@
+package skiplist
+
+// Get the node at the given position in the skiplist,
+// where the first node is at index 0.
+// Returns nil if the index is out of range.
+// Average complexity: O(log(n)) WithRanks, else O(n)
+func (l *SkipList[T]) At(index int) *Node[T] {
+	if index < 0 || index >= l.length {
+		return nil
+	}
+	if !l.ranks {
+		// walk from the closest end.
+		if index < l.length/2 {
+			node := l.head.lanes[0]
+			for ; index > 0; index-- {
+				node = node.lanes[0]
+			}
+			return node
+		}
+		node := l.tail.prevs[0]
+		for index = l.length - 1 - index; index > 0; index-- {
+			node = node.prevs[0]
+		}
+		return node
+	}
+	// the head is at rank 0 and the first node at rank 1.
+	node, rank := l.head, 0
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		for ; rank+node.meta.spans[levelIdx] <= index+1; node = node.lanes[levelIdx] {
+			rank += node.meta.spans[levelIdx]
+		}
+	}
+	return node
+}
+
+// Get the position of a node in the skiplist, where
+// the first node is at index 0.
+// The node must be part of the skiplist.
+// Average complexity: O(log(n)) WithRanks, else O(n)
+func (l *SkipList[T]) IndexOf(node *Node[T]) int {
+	index := -1
+	if !l.ranks {
+		for ; node != l.head; node = node.prevs[0] {
+			index++
+		}
+		return index
+	}
+	// climb backward along the highest lane of each
+	// node, summing the spans of the lanes leading
+	// to the visited nodes.
+	for node != l.head {
+		levelIdx := len(node.lanes) - 1
+		node = node.prevs[levelIdx]
+		index += node.meta.spans[levelIdx]
+	}
+	return index
+}
+
+// Get the number of nodes with a value
+// less than the given value.
+// Average complexity: O(log(n)) WithRanks,
+// else O(log(n) + CountLess(value))
+func (l *SkipList[T]) CountLess(value T) int {
+	if l.ranks {
+		_, index := l.searchIndex(value)
+		return index
+	}
+	n := 0
+	for node := l.searchLT(value); node != nil; node = node.Prev() {
+		n++
+	}
+	return n
+}
+
+// Get the number of nodes with a value
+// greater than or equal to the given value.
+// Average complexity: O(log(n)) WithRanks,
+// else O(log(n) + CountGreaterOrEqual(value))
+func (l *SkipList[T]) CountGreaterOrEqual(value T) int {
+	if l.ranks {
+		_, index := l.searchIndex(value)
+		return l.length - index
+	}
+	n := 0
+	for node := l.Search(value); node != nil; node = node.Next() {
+		n++
+	}
+	return n
+}
+
+// Find the first node with a value that is greater
+// or equal to the given value along with its index.
+// The node is the tail sentinel if no such node exists.
+// Must only be used WithRanks.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) searchIndex(value T) (node *Node[T], index int) {
+	comparisons := 0
+	pred, rank := l.head, 0
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		for ; pred.lanes[levelIdx] != l.tail && l.searchLess(&comparisons, pred.lanes[levelIdx].value, value); pred = pred.lanes[levelIdx] {
+			rank += pred.meta.spans[levelIdx]
+		}
+	}
+	// the rank of the predecessor is the
+	// index of the succeeding node.
+	return pred.lanes[0], rank
+}
+
+// Update the spans of the node and its predecessors
+// before the node is linked after the predecessors.
+func (l *SkipList[T]) linkSpans(
+	node *Node[T],
+	preds *[MaxLevel]*Node[T],
+) {
+	if len(node.meta.spans) != len(node.lanes) {
+		// node was created by another skiplist
+		node.meta.spans = make([]int, len(node.lanes))
+	}
+	// distance from the predecessor to the
+	// new node for the current level.
+	dist := 1
+	for levelIdx, pred := range preds {
+		if levelIdx > 0 {
+			for p := pred; p != preds[levelIdx-1]; p = p.lanes[levelIdx-1] {
+				dist += p.meta.spans[levelIdx-1]
+			}
+		}
+		if levelIdx < len(node.lanes) {
+			node.meta.spans[levelIdx] = pred.meta.spans[levelIdx] - dist + 1
+			pred.meta.spans[levelIdx] = dist
+		} else {
+			pred.meta.spans[levelIdx]++
+		}
+	}
+}
+
+// Update the spans of the nodes with lanes leading
+// to or over the node before it is unlinked.
+func (l *SkipList[T]) unlinkSpans(node *Node[T]) {
+	for levelIdx, prev := range node.prevs {
+		prev.meta.spans[levelIdx] += node.meta.spans[levelIdx] - 1
+	}
+	// climb backward to find the nodes with
+	// lanes passing over the node.
+	pred := node
+	for levelIdx := len(node.lanes); levelIdx < MaxLevel; levelIdx++ {
+		for len(pred.lanes) <= levelIdx {
+			pred = pred.prevs[len(pred.lanes)-1]
+		}
+		pred.meta.spans[levelIdx]--
+	}
+}
+
+var _ Option = (*withRanks)(nil)
+
+type withRanks struct{}
+
+func (o *withRanks) apply(opts *options) {
+	opts.ranks = true
+}
+
+// Track the number of nodes skipped by every lane,
+// enabling positional queries such as At and IndexOf
+// in O(log(n)). Costs an int per lane and makes removal
+// of the first and last nodes O(log(n)).
+func WithRanks() Option {
+	return &withRanks{}
+}
+
+// The number of nodes of a level needed
+// for ApproxMiddle to pick from that level.
+const approxMiddleSamples = 32
+
+// Get a node near the middle of the skiplist, for
+// splitting work in divide-and-conquer algorithms.
+// WithRanks the node is exactly in the middle, else the
+// middle node of the highest level that has enough nodes
+// to represent the distribution of the values is used.
+// Returns nil if the skiplist is empty.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) ApproxMiddle() *Node[T] {
+	if l.ranks || l.length < 2*approxMiddleSamples {
+		return l.At((l.length - 1) / 2)
+	}
+	for levelIdx := MaxLevel - 1; levelIdx > 0; levelIdx-- {
+		n := 0
+		for node := l.head.lanes[levelIdx]; node != l.tail; node = node.lanes[levelIdx] {
+			n++
+		}
+		if n < approxMiddleSamples {
+			continue
+		}
+		node := l.head.lanes[levelIdx]
+		for i := (n - 1) / 2; i > 0; i-- {
+			node = node.lanes[levelIdx]
+		}
+		return node
+	}
+	return l.At((l.length - 1) / 2)
+}
+
+// A value of the skiplist along with its index.
+type IndexEntry[T any] struct {
+	Value T
+	// The index of the value, where the
+	// first value is at index 0.
+	Index int
+}
+
+// Get every k-th value along with its index, starting with
+// the first value, as a sparse index of the skiplist.
+// External systems can binary search the sparse index to
+// find the range of indices a value is in, and it
+// shows the distribution of the values cheaply.
+// Complexity: O(n)
+func (l *SkipList[T]) SparseIndex(k int) []IndexEntry[T] {
+	if k < 1 {
+		k = 1
+	}
+	entries := make([]IndexEntry[T], 0, (l.length+k-1)/k)
+	index := 0
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		if index%k == 0 {
+			entries = append(entries, IndexEntry[T]{Value: node.value, Index: index})
+		}
+		index++
+	}
+	return entries
+}
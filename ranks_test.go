@@ -0,0 +1,137 @@
+package skiplist_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRanks(t *testing.T) {
+	const numElem = 1 << 12
+	requireRanks := func(t *testing.T, sl *skiplist.SkipList[int], sortedData []int) {
+		require.Nil(t, sl.At(-1))
+		require.Nil(t, sl.At(len(sortedData)))
+		for i := range sortedData {
+			node := sl.At(i)
+			require.NotNil(t, node)
+			require.Equal(t, sortedData[i], node.Value())
+			require.Equal(t, i, sl.IndexOf(node))
+		}
+	}
+	for _, opts := range [][]skiplist.Option{
+		{},
+		{skiplist.WithRanks()},
+		{skiplist.WithRanks(), skiplist.WithReplace()},
+		{skiplist.WithRanks(), skiplist.WithDetachOnRemove()},
+	} {
+		sl := skiplist.New(less[int], opts...)
+		order := rand.Perm(numElem)
+		addAll(t, sl, order)
+		sortedData := make([]int, numElem)
+		for i := range sortedData {
+			sortedData[i] = i
+		}
+		requireRanks(t, sl, sortedData)
+		// remove values in a random order from the front,
+		// back and middle of the skiplist.
+		for i := 0; i < numElem/2; i++ {
+			var node *skiplist.Node[int]
+			switch i % 4 {
+			case 0:
+				node = sl.RemoveFirst()
+			case 1:
+				node = sl.RemoveLast()
+			case 2:
+				node = sl.Remove(order[i])
+			default:
+				node = sl.Search(order[i])
+				if node != nil {
+					node = node.RemoveFrom(sl)
+				}
+			}
+			if node != nil {
+				idx := -1
+				for j := range sortedData {
+					if sortedData[j] == node.Value() {
+						idx = j
+					}
+				}
+				sortedData = append(sortedData[:idx], sortedData[idx+1:]...)
+			}
+		}
+		requireRanks(t, sl, sortedData)
+		sl.Clear()
+		requireRanks(t, sl, nil)
+		addAll(t, sl, order)
+		sortedData = sortedData[:numElem]
+		for i := range sortedData {
+			sortedData[i] = i
+		}
+		requireRanks(t, sl, sortedData)
+	}
+	t.Run("WithReplace", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithRanks(), skiplist.WithReplace())
+		sortedData := make([]int, numElem)
+		for i := range sortedData {
+			sortedData[i] = i
+		}
+		addAll(t, sl, rand.Perm(numElem))
+		for _, v := range rand.Perm(numElem) {
+			_, replaced := sl.Add(v)
+			require.NotNil(t, replaced)
+		}
+		requireRanks(t, sl, sortedData)
+	})
+}
+
+func TestApproxMiddle(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+		sl := skiplist.New(less[int], opts...)
+		require.Nil(t, sl.ApproxMiddle())
+		sl.Add(0)
+		require.Equal(t, 0, sl.ApproxMiddle().Value())
+		sl.Add(1)
+		require.Equal(t, 0, sl.ApproxMiddle().Value())
+		sl.Add(2)
+		require.Equal(t, 1, sl.ApproxMiddle().Value())
+		for i := 3; i < 100000; i++ {
+			sl.Add(i)
+		}
+		middle := sl.ApproxMiddle().Value()
+		require.InDelta(t, 50000, middle, 15000)
+		if opts != nil {
+			require.Equal(t, 49999, middle)
+		}
+	}
+}
+
+func TestCountLess(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+		sl := skiplist.New(less[int], opts...)
+		require.Equal(t, 0, sl.CountLess(0))
+		require.Equal(t, 0, sl.CountGreaterOrEqual(0))
+		addAll(t, sl, []int{1, 3, 3, 5, 7})
+		for value, expected := range map[int]int{0: 0, 1: 0, 2: 1, 3: 1, 4: 3, 7: 4, 8: 5} {
+			require.Equal(t, expected, sl.CountLess(value), value)
+			require.Equal(t, 5-expected, sl.CountGreaterOrEqual(value), value)
+		}
+	}
+}
+
+func TestSparseIndex(t *testing.T) {
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+		sl := skiplist.New(less[int], opts...)
+		require.Empty(t, sl.SparseIndex(10))
+		for i := 0; i < 25; i++ {
+			sl.Add(i * 2)
+		}
+		require.Equal(t, []skiplist.IndexEntry[int]{
+			{Value: 0, Index: 0},
+			{Value: 20, Index: 10},
+			{Value: 40, Index: 20},
+		}, sl.SparseIndex(10))
+		require.Len(t, sl.SparseIndex(0), 25)
+	}
+}
@@ -0,0 +1,294 @@
+package skiplist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Writes the operations performed on a
+// skiplist WithRecorder.
+type recorder[T any] struct {
+	w      io.Writer
+	encode func(T) string
+	// The first write error, after which
+	// nothing more is recorded.
+	err error
+}
+
+// Record an operation with its arguments.
+func (r *recorder[T]) write(op string, args ...string) {
+	if r.err != nil {
+		return
+	}
+	line := op
+	for _, arg := range args {
+		line += " " + arg
+	}
+	_, r.err = io.WriteString(r.w, line+"\n")
+}
+
+// Encode and quote a value as an argument of an operation.
+func (r *recorder[T]) quote(value T) string {
+	return strconv.Quote(r.encode(value))
+}
+
+// Record an operation on a value if a recorder is set.
+func (l *SkipList[T]) record(op string, value T, args ...string) {
+	if l.recorder != nil {
+		l.recorder.write(op, append([]string{l.recorder.quote(value)}, args...)...)
+	}
+}
+
+// Record an operation without a value
+// if a recorder is set.
+func (l *SkipList[T]) recordOp(op string) {
+	if l.recorder != nil {
+		l.recorder.write(op)
+	}
+}
+
+// Record an operation on a node if a recorder is set.
+// The node is identified by its value and the number of
+// nodes with an equal value preceding it.
+func (l *SkipList[T]) recordNode(op string, node *Node[T], args ...string) {
+	if l.recorder == nil {
+		return
+	}
+	offset := 0
+	if !l.counts && !l.replace {
+		for prev := node.prevs[0]; prev != l.head && !l.less(prev.value, node.value); prev = prev.prevs[0] {
+			offset++
+		}
+	}
+	l.recorder.write(op, append([]string{l.recorder.quote(node.value), strconv.Itoa(offset)}, args...)...)
+}
+
+// Encode and quote a value as an argument of
+// an operation if a recorder is set.
+func (l *SkipList[T]) quote(value T) string {
+	if l.recorder == nil {
+		return ""
+	}
+	return l.recorder.quote(value)
+}
+
+// The arguments of every operation written WithRecorder,
+// where v is a value and i an integer.
+var recordedArgs = map[string]string{
+	"add":         "v",
+	"append":      "vi",
+	"newnode":     "v",
+	"addnode":     "vii",
+	"remove":      "vi",
+	"removefirst": "",
+	"removelast":  "",
+	"clear":       "",
+	"unlink":      "vi",
+	"update":      "viv",
+	"rebuild":     "",
+	"relevel":     "vii",
+}
+
+// Returned by Replay when a recorded node
+// does not exist in the skiplist.
+var errRecordedNode = errors.New("recorded node not found")
+
+// Apply the operations written WithRecorder to the
+// skiplist, in order. Values are decoded by decode.
+// Create the skiplist with the options of the recorded
+// skiplist, including the same random number generator,
+// to reproduce its structure exactly. Evictions
+// WithWeightLimit and rebuilds WithAdaptiveLevels are
+// replayed as recorded instead of being made by the
+// skiplist while replaying.
+// Complexity: O(k*log(n)) for k operations
+func (l *SkipList[T]) Replay(
+	r io.Reader,
+	decode func(value string) (T, error),
+) error {
+	l.replaying = true
+	defer func() {
+		l.replaying = false
+	}()
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if err := l.replayLine(scanner.Text(), decode); err != nil {
+			return fmt.Errorf("skiplist: line %d: %w", lineNum, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Apply a single recorded operation.
+func (l *SkipList[T]) replayLine(
+	line string,
+	decode func(value string) (T, error),
+) error {
+	op, rest, _ := strings.Cut(line, " ")
+	var (
+		values []T
+		ints   []int
+		// the kinds of the arguments, in order.
+		args string
+	)
+	for rest != "" {
+		var arg string
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return err
+			}
+			encoded, _ := strconv.Unquote(quoted)
+			value, err := decode(encoded)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+			args += "v"
+			arg, rest = quoted, rest[len(quoted):]
+		} else {
+			arg, _, _ = strings.Cut(rest, " ")
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return err
+			}
+			ints = append(ints, n)
+			args += "i"
+			rest = rest[len(arg):]
+		}
+		if rest != "" {
+			if rest[0] != ' ' {
+				return fmt.Errorf("invalid argument %q", arg+rest)
+			}
+			rest = rest[1:]
+		}
+	}
+	expected, ok := recordedArgs[op]
+	if !ok || args != expected {
+		return fmt.Errorf("invalid operation %q", line)
+	}
+	for _, n := range ints {
+		if n < 0 {
+			return fmt.Errorf("invalid operation %q", line)
+		}
+	}
+	var node *Node[T]
+	switch op {
+	case "remove", "unlink", "update", "relevel":
+		if node = l.recordedNode(values[0], ints[0]); node == nil {
+			return errRecordedNode
+		}
+	case "append", "addnode":
+		if ints[0] < 1 || ints[0] > MaxLevel {
+			return fmt.Errorf("invalid level %d", ints[0])
+		}
+	}
+	switch op {
+	case "add":
+		l.Add(values[0])
+	case "append":
+		l.record("append", values[0], strconv.Itoa(ints[0]))
+		l.linkLast(l.newNode(values[0], ints[0]), l.callOptions(values[0]))
+	case "newnode":
+		l.NewNode(values[0], 0)
+	case "addnode":
+		node = l.newNode(values[0], ints[0])
+		if node.meta != nil && ints[1] > 1 {
+			node.meta.dups = ints[1] - 1
+		}
+		l.AddNode(node)
+	case "remove":
+		l.recordNode("remove", node)
+		l.release(node)
+	case "removefirst":
+		l.RemoveFirst()
+	case "removelast":
+		l.RemoveLast()
+	case "clear":
+		l.Clear()
+	case "unlink":
+		l.recordNode("unlink", node)
+		l.unlink(node)
+	case "update":
+		l.update(node, values[1])
+	case "rebuild":
+		l.Rebuild()
+	case "relevel":
+		if ints[1] < 1 || ints[1] > MaxLevel {
+			return fmt.Errorf("invalid level %d", ints[1])
+		}
+		var preds [MaxLevel]*Node[T]
+		pred := node.prevs[0]
+		for levelIdx := range preds {
+			// the last preceding node with
+			// a lane at the level.
+			for len(pred.lanes) <= levelIdx {
+				pred = pred.prevs[len(pred.lanes)-1]
+			}
+			preds[levelIdx] = pred
+		}
+		l.relevel(node, ints[1], &preds)
+	}
+	return nil
+}
+
+// Find the node identified by its value and the number
+// of nodes with an equal value preceding it.
+// Returns nil if there is no such node.
+func (l *SkipList[T]) recordedNode(value T, offset int) *Node[T] {
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(value, &preds)
+	node := preds[0].lanes[0]
+	for ; offset > 0 && node != l.tail; offset-- {
+		node = node.lanes[0]
+	}
+	if node == l.tail || l.less(value, node.value) {
+		return nil
+	}
+	return node
+}
+
+var _ Option = (*withRecorder)(nil)
+
+type withRecorder struct {
+	w io.Writer
+	// A func(T) string, the type parameter
+	// is not known to the options.
+	encode any
+}
+
+func (o *withRecorder) apply(opts *options) {
+	opts.recordTo = o.w
+	opts.recordEncode = o.encode
+}
+
+// Write every operation that modifies the skiplist to w,
+// so that the operations can be replayed with Replay to
+// reproduce a bug. This includes the operations made by
+// other methods, such as ReadCSV and Drain, the changes
+// the skiplist makes itself, such as evictions
+// WithWeightLimit, and the random levels drawn by NewNode.
+// Every operation is written as a line with its name in
+// lower case followed by its arguments separated by
+// spaces, where values are encoded by encode and quoted
+// with strconv.Quote:
+//
+//	add "1"
+//	remove "1" 0
+//	removefirst
+//
+// A node is identified by its value followed by the
+// number of nodes with an equal value preceding it.
+// Writes are not buffered. Recording stops at the
+// first write error.
+// The type parameter must match that of the skiplist.
+func WithRecorder[T any](w io.Writer, encode func(T) string) Option {
+	return &withRecorder{
+		w:      w,
+		encode: encode,
+	}
+}
@@ -0,0 +1,289 @@
+package skiplist
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// First line of a registry snapshot.
+const registryHeader = "skiplist registry v1"
+
+// A Registry manages named skiplists sharing a comparator,
+// such as one skiplist per tenant or topic. Skiplists are
+// created on first use. It is not threadsafe.
+type Registry[T any] struct {
+	less func(a, b T) bool
+	// Options of every skiplist.
+	opts  []Option
+	lists map[string]*registered[T]
+}
+
+// A skiplist of a registry.
+type registered[T any] struct {
+	list *SkipList[T]
+	// Options of the skiplist in addition
+	// to those of the registry.
+	opts  []Option
+	quota Quota[T]
+	// The sum of the sizes of the values added
+	// and removed through the registry.
+	bytes int
+}
+
+// Limits of a skiplist of a registry, enforced by
+// Registry.Add. A zero limit disables the limit.
+type Quota[T any] struct {
+	// The maximum number of nodes.
+	MaxLength int
+	// The maximum sum of the sizes of the values
+	// as given by Size.
+	MaxBytes int
+	// The size of a value in bytes.
+	// Required when MaxBytes is set.
+	Size func(T) int
+}
+
+// Returned by Registry.Add when adding a value
+// would exceed the quota of a skiplist.
+type QuotaError struct {
+	// The name of the skiplist.
+	List string
+	// The exceeded limit, "length" or "bytes".
+	Limit string
+	// The value of the exceeded limit.
+	Max int
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("skiplist: quota of %q exceeded: %s limit %d", e.List, e.Limit, e.Max)
+}
+
+// Statistics about the skiplists of a registry.
+type RegistryStats struct {
+	// The number of skiplists.
+	Lists int
+	// The total number of nodes of all skiplists.
+	Length int
+	// The statistics of each skiplist by name.
+	ByList map[string]Stats
+}
+
+// Create a registry of skiplists ordered by less.
+// The options are applied to every skiplist.
+func NewRegistry[T any](less func(a, b T) bool, opts ...Option) *Registry[T] {
+	return &Registry[T]{
+		less:  less,
+		opts:  opts,
+		lists: make(map[string]*registered[T]),
+	}
+}
+
+// Get the skiplist with the given name, creating it if
+// it does not exist. The options are applied after the
+// options of the registry when the skiplist is created,
+// and ignored otherwise.
+func (r *Registry[T]) Get(name string, opts ...Option) *SkipList[T] {
+	if reg, ok := r.lists[name]; ok {
+		return reg.list
+	}
+	reg := &registered[T]{
+		list: New(r.less, r.options(opts)...),
+		opts: opts,
+	}
+	r.lists[name] = reg
+	return reg.list
+}
+
+// Set the quota of the skiplist with the given name,
+// creating the skiplist if it does not exist.
+// Values added before the quota is set are not accounted
+// for in the sum of the sizes of the values.
+func (r *Registry[T]) SetQuota(name string, quota Quota[T]) {
+	r.Get(name)
+	r.lists[name].quota = quota
+}
+
+// Insert a value into the skiplist with the given name,
+// creating it if it does not exist. Returns a QuotaError
+// if the value would exceed the quota of the skiplist.
+// Only values added and removed through the registry are
+// accounted for in the sum of the sizes of the values.
+// Average complexity: O(log(n))
+func (r *Registry[T]) Add(name string, value T) (*Node[T], error) {
+	r.Get(name)
+	reg := r.lists[name]
+	q := reg.quota
+	// the equal value replaced WithReplace
+	// or counted WithCounts, if any.
+	var existing *Node[T]
+	if (q.MaxLength > 0 || q.MaxBytes > 0) && (reg.list.replace || reg.list.counts) {
+		if existing = reg.list.Search(value); existing != nil && r.less(value, existing.value) {
+			existing = nil
+		}
+	}
+	if q.MaxLength > 0 && existing == nil && reg.list.Length() >= q.MaxLength {
+		return nil, &QuotaError{List: name, Limit: "length", Max: q.MaxLength}
+	}
+	size := 0
+	if q.MaxBytes > 0 {
+		size = q.Size(value)
+		if existing != nil && reg.list.replace {
+			size -= q.Size(existing.value)
+		}
+		if reg.bytes+size > q.MaxBytes {
+			return nil, &QuotaError{List: name, Limit: "bytes", Max: q.MaxBytes}
+		}
+	}
+	node, _ := reg.list.Add(value)
+	if node == nil {
+		return nil, ErrFull
+	}
+	reg.bytes += size
+	return node, nil
+}
+
+// Remove the first value equal to the given value from
+// the skiplist with the given name, see SkipList.Remove.
+// Returns nil if the skiplist or the value does not exist.
+// Average complexity: O(log(n))
+func (r *Registry[T]) Remove(name string, value T) *Node[T] {
+	reg, ok := r.lists[name]
+	if !ok {
+		return nil
+	}
+	node := reg.list.Remove(value)
+	if node != nil && reg.quota.MaxBytes > 0 {
+		reg.bytes -= reg.quota.Size(node.value)
+	}
+	return node
+}
+
+// Get the skiplist with the given name.
+// Returns false if it does not exist.
+func (r *Registry[T]) Lookup(name string) (*SkipList[T], bool) {
+	reg, ok := r.lists[name]
+	if !ok {
+		return nil, false
+	}
+	return reg.list, true
+}
+
+// Remove the skiplist with the given name from the registry.
+// Returns false if it does not exist.
+func (r *Registry[T]) Delete(name string) bool {
+	_, ok := r.lists[name]
+	delete(r.lists, name)
+	return ok
+}
+
+// Get the names of the skiplists in ascending order.
+func (r *Registry[T]) Names() []string {
+	names := make([]string, 0, len(r.lists))
+	for name := range r.lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Collect statistics about every skiplist.
+// Complexity: O(n) for n nodes in all skiplists
+func (r *Registry[T]) Stats() RegistryStats {
+	s := RegistryStats{
+		Lists:  len(r.lists),
+		ByList: make(map[string]Stats, len(r.lists)),
+	}
+	for name, reg := range r.lists {
+		stats := reg.list.Stats()
+		s.Length += stats.Length
+		s.ByList[name] = stats
+	}
+	return s
+}
+
+// Write every skiplist to w in the format of
+// DumpStructure, preceded by its name, so that
+// the registry can be restored with Restore.
+// Complexity: O(n) for n nodes in all skiplists
+func (r *Registry[T]) Snapshot(w io.Writer, encode func(value T) string) error {
+	if _, err := fmt.Fprintln(w, registryHeader); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, name := range r.Names() {
+		buf.Reset()
+		if err := r.lists[name].list.DumpStructure(&buf, encode); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s %d\n", strconv.Quote(name), buf.Len()); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace the skiplists of the registry with those of a
+// snapshot written by Snapshot. Skiplists that exist in
+// the registry keep their options and quotas, others are
+// created with the options of the registry. Quotas are
+// not enforced while restoring. Skiplists that are
+// not in the snapshot are removed.
+// The registry is left unmodified if an error is returned.
+// Complexity: O(n) for n nodes in all skiplists
+func (r *Registry[T]) Restore(src io.Reader, decode func(value string) (T, error)) error {
+	br := bufio.NewReader(src)
+	header, err := br.ReadString('\n')
+	if err != nil || strings.TrimSuffix(header, "\n") != registryHeader {
+		return fmt.Errorf("skiplist: invalid registry snapshot header %q", header)
+	}
+	lists := make(map[string]*registered[T])
+	for {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		quoted, sizeStr, ok := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+		name, nameErr := strconv.Unquote(quoted)
+		size, sizeErr := strconv.ParseInt(sizeStr, 10, 64)
+		if !ok || nameErr != nil || sizeErr != nil || size < 0 {
+			return fmt.Errorf("skiplist: invalid registry snapshot entry %q", line)
+		}
+		var (
+			opts  []Option
+			quota Quota[T]
+		)
+		if reg, ok := r.lists[name]; ok {
+			opts, quota = reg.opts, reg.quota
+		}
+		list, err := LoadStructure(io.LimitReader(br, size), r.less, decode, r.options(opts)...)
+		if err != nil {
+			return fmt.Errorf("skiplist: registry snapshot list %q: %w", name, err)
+		}
+		reg := &registered[T]{list: list, opts: opts, quota: quota}
+		if quota.MaxBytes > 0 {
+			for node := list.First(); node != nil; node = node.Next() {
+				reg.bytes += quota.Size(node.value) * node.Count()
+			}
+		}
+		lists[name] = reg
+	}
+	r.lists = lists
+	return nil
+}
+
+// Get the options of the registry followed
+// by the options of a skiplist.
+func (r *Registry[T]) options(opts []Option) []Option {
+	return append(r.opts[:len(r.opts):len(r.opts)], opts...)
+}
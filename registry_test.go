@@ -0,0 +1,91 @@
+package skiplist_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := skiplist.NewRegistry(less[int], skiplist.WithRanks())
+	_, ok := r.Lookup("a")
+	require.False(t, ok)
+	a := r.Get("a")
+	require.Same(t, a, r.Get("a"))
+	b := r.Get("b", skiplist.WithReplace())
+	addAll(t, a, []int{3, 1, 2})
+	addAll(t, b, []int{5, 5, 4})
+	require.Equal(t, []string{"a", "b"}, r.Names())
+	require.Equal(t, 1, a.At(0).Value())
+
+	s := r.Stats()
+	require.Equal(t, 2, s.Lists)
+	require.Equal(t, 5, s.Length)
+	require.Equal(t, 3, s.ByList["a"].Length)
+	require.Equal(t, 2, s.ByList["b"].Length)
+
+	var snapshot bytes.Buffer
+	require.NoError(t, r.Snapshot(&snapshot, strconv.Itoa))
+	require.True(t, r.Delete("a"))
+	require.False(t, r.Delete("a"))
+	r.Get("c")
+	require.Equal(t, []string{"b", "c"}, r.Names())
+
+	require.NoError(t, r.Restore(bytes.NewReader(snapshot.Bytes()), strconv.Atoi))
+	require.Equal(t, []string{"a", "b"}, r.Names())
+	a, _ = r.Lookup("a")
+	require.Equal(t, []int{1, 2, 3}, a.FirstN(3))
+	require.Equal(t, 2, a.At(1).Value())
+	b, _ = r.Lookup("b")
+	require.Equal(t, []int{4, 5}, b.FirstN(3))
+	// the restored skiplist keeps its options.
+	b.Add(4)
+	require.Equal(t, 2, b.Length())
+
+	require.Error(t, r.Restore(strings.NewReader("invalid\n"), strconv.Atoi))
+	require.Error(t, r.Restore(strings.NewReader("skiplist registry v1\n\"a\" 5\n1 \"x\"\n"), strconv.Atoi))
+	require.Equal(t, []string{"a", "b"}, r.Names())
+}
+
+func TestRegistryQuota(t *testing.T) {
+	r := skiplist.NewRegistry(less[string], skiplist.WithReplace())
+	r.SetQuota("a", skiplist.Quota[string]{MaxLength: 2})
+	r.SetQuota("b", skiplist.Quota[string]{
+		MaxBytes: 5,
+		Size:     func(v string) int { return len(v) },
+	})
+	_, err := r.Add("a", "x")
+	require.NoError(t, err)
+	_, err = r.Add("a", "y")
+	require.NoError(t, err)
+	_, err = r.Add("a", "z")
+	var quotaErr *skiplist.QuotaError
+	require.ErrorAs(t, err, &quotaErr)
+	require.Equal(t, "a", quotaErr.List)
+	require.Equal(t, "length", quotaErr.Limit)
+
+	_, err = r.Add("b", "abc")
+	require.NoError(t, err)
+	_, err = r.Add("b", "def")
+	require.ErrorAs(t, err, &quotaErr)
+	require.Equal(t, "bytes", quotaErr.Limit)
+	require.NotNil(t, r.Remove("b", "abc"))
+	_, err = r.Add("b", "def")
+	require.NoError(t, err)
+	_, err = r.Add("b", "gh")
+	require.NoError(t, err)
+	// a replaced value frees its size.
+	_, err = r.Add("b", "gh")
+	require.NoError(t, err)
+	require.Nil(t, r.Remove("c", "x"))
+
+	// other skiplists are unaffected.
+	for i := 0; i < 10; i++ {
+		_, err = r.Add("c", strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+}
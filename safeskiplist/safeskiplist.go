@@ -0,0 +1,155 @@
+// Package safeskiplist wraps a skiplist so that panics
+// raised while operating on it, such as a panicking
+// comparator, are returned as errors instead, for
+// services where a panic from a library is unacceptable.
+package safeskiplist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+var (
+	// Returned by New when the comparator is nil.
+	ErrNilComparator = errors.New("safeskiplist: nil comparator")
+	// Returned by RemoveNode when the node is
+	// not part of the skiplist.
+	ErrNodeNotFound = errors.New("safeskiplist: node not found")
+)
+
+// Returned when an operation panics.
+type PanicError struct {
+	// The value passed to panic.
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("safeskiplist: panic: %v", e.Value)
+}
+
+// Returns the value passed to panic
+// if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Returned when the structure of the skiplist is found
+// to be inconsistent after an operation, see
+// SkipList.CheckInvariants.
+type InvariantError struct {
+	Err error
+}
+
+func (e *InvariantError) Error() string {
+	return "safeskiplist: invariant violated: " + e.Err.Error()
+}
+
+func (e *InvariantError) Unwrap() error {
+	return e.Err
+}
+
+// A SkipList wraps a skiplist.SkipList, returning errors
+// instead of panicking. Like the wrapped skiplist it is
+// not threadsafe.
+type SkipList[T any] struct {
+	list  *skiplist.SkipList[T]
+	check bool
+}
+
+// Create a new skiplist.
+// Returns an error if the comparator is nil
+// or an option panics.
+func New[T any](
+	less func(a, b T) bool,
+	opts ...skiplist.Option,
+) (l *SkipList[T], err error) {
+	if less == nil {
+		return nil, ErrNilComparator
+	}
+	defer recoverPanic(&err)
+	return &SkipList[T]{
+		list: skiplist.New(less, opts...),
+	}, nil
+}
+
+// Check the structure of the skiplist after every
+// modification, returning an InvariantError if it
+// is inconsistent. This makes every modification
+// O(n) and is meant for debugging.
+func (l *SkipList[T]) CheckInvariants(enabled bool) {
+	l.check = enabled
+}
+
+// Get the wrapped skiplist. Operations on it are
+// not protected against panics.
+func (l *SkipList[T]) List() *skiplist.SkipList[T] {
+	return l.list
+}
+
+// Returns the number of nodes in the skiplist.
+func (l *SkipList[T]) Length() int {
+	return l.list.Length()
+}
+
+// Insert a value into the skiplist, see skiplist.SkipList.Add.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Add(value T) (node, replacedNode *skiplist.Node[T], err error) {
+	defer recoverPanic(&err)
+	node, replacedNode = l.list.Add(value)
+	if node == nil {
+		return nil, nil, skiplist.ErrFull
+	}
+	return node, replacedNode, l.checkInvariants()
+}
+
+// Find the first node with a value greater than or equal
+// to the given value, see skiplist.SkipList.Search.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Search(value T) (node *skiplist.Node[T], err error) {
+	defer recoverPanic(&err)
+	return l.list.Search(value), nil
+}
+
+// Remove the first node with the given value,
+// see skiplist.SkipList.Remove.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Remove(value T) (node *skiplist.Node[T], err error) {
+	defer recoverPanic(&err)
+	node = l.list.Remove(value)
+	return node, l.checkInvariants()
+}
+
+// Remove the node from the skiplist. Returns
+// ErrNodeNotFound if the node is not part of it.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) RemoveNode(node *skiplist.Node[T]) (err error) {
+	defer recoverPanic(&err)
+	if node.RemoveFrom(l.list) == nil {
+		return ErrNodeNotFound
+	}
+	return l.checkInvariants()
+}
+
+// Check the structure of the skiplist
+// if enabled by CheckInvariants.
+func (l *SkipList[T]) checkInvariants() error {
+	if !l.check {
+		return nil
+	}
+	if err := l.list.CheckStructure(); err != nil {
+		return &InvariantError{Err: err}
+	}
+	return nil
+}
+
+// Recover from a panic and store it as a
+// PanicError in err.
+// Must be called directly by a deferred call.
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{Value: r}
+	}
+}
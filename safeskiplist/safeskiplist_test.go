@@ -0,0 +1,57 @@
+package safeskiplist_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/safeskiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipList(t *testing.T) {
+	_, err := safeskiplist.New[int](nil)
+	require.ErrorIs(t, err, safeskiplist.ErrNilComparator)
+	_, err = safeskiplist.New(
+		func(a, b int) bool { return a < b },
+		skiplist.WithWeightLimit(func(string) int { return 0 }, 0, skiplist.Front),
+	)
+	var panicErr *safeskiplist.PanicError
+	require.ErrorAs(t, err, &panicErr)
+
+	boom := errors.New("boom")
+	panicking := false
+	sl, err := safeskiplist.New(func(a, b int) bool {
+		if panicking {
+			panic(boom)
+		}
+		return a < b
+	}, skiplist.WithHardLimit(3))
+	require.NoError(t, err)
+	sl.CheckInvariants(true)
+	for i := 0; i < 3; i++ {
+		_, _, err := sl.Add(i)
+		require.NoError(t, err)
+	}
+	_, _, err = sl.Add(3)
+	require.ErrorIs(t, err, skiplist.ErrFull)
+
+	panicking = true
+	_, _, err = sl.Add(4)
+	require.ErrorIs(t, err, boom)
+	_, err = sl.Search(1)
+	require.ErrorIs(t, err, boom)
+	_, err = sl.Remove(1)
+	require.ErrorIs(t, err, boom)
+	panicking = false
+	require.Equal(t, 3, sl.Length())
+
+	node, err := sl.Search(1)
+	require.NoError(t, err)
+	require.NoError(t, sl.RemoveNode(node))
+	require.ErrorIs(t, sl.RemoveNode(node), safeskiplist.ErrNodeNotFound)
+	node, err = sl.Remove(2)
+	require.NoError(t, err)
+	require.Equal(t, 2, node.Value())
+	require.Equal(t, 1, sl.List().Length())
+}
@@ -0,0 +1,144 @@
+package skiplist
+
+// A Scanner walks the values of a skiplist in ascending
+// order while keeping track of how far it has come.
+// The position of a scanner can be captured with
+// Checkpoint and later restored with ResumeScan, which
+// allows long running scans to survive restarts.
+//
+// The node most recently returned by the scanner may be
+// removed from the skiplist without disrupting the scan,
+// unless the skiplist was created WithFailFast in which
+// case any modification of the skiplist stops the scanner
+// with ErrConcurrentModification.
+type Scanner[T any] struct {
+	list *SkipList[T]
+	// The generation of the skiplist when the
+	// scanner was created.
+	generation uint64
+	err        error
+	// The node returned by the previous call to Next.
+	node *Node[T]
+	// The node to be returned by the next call to Next.
+	next *Node[T]
+	// The value of the last visited node.
+	value   T
+	visited int
+}
+
+// A Checkpoint holds the position of a Scanner.
+// All fields are exported so that the checkpoint
+// can be persisted by the caller.
+type Checkpoint[T any] struct {
+	// The value of the last node visited.
+	Value T
+	// The number of nodes with a value equal to Value
+	// following the last node visited, which were not
+	// visited when the checkpoint was taken.
+	Remaining int
+	// The number of nodes visited in total.
+	Visited int
+}
+
+// Create a scanner positioned before the first
+// node in the skiplist.
+func (l *SkipList[T]) Scan() *Scanner[T] {
+	return &Scanner[T]{
+		list:       l,
+		generation: l.generation,
+		next:       l.First(),
+	}
+}
+
+// Create a scanner that continues from the position
+// recorded in the given checkpoint. Nodes that were
+// visited before the checkpoint was taken are not
+// visited again, as values equal to the value of the
+// checkpoint are added before the equal values already
+// in the skiplist. The position is counted from the last
+// equal value, so removing equal values that had not
+// been visited makes the scanner visit as many of the
+// visited equal values again.
+// Average complexity: O(log(n) + k) where k is the
+// number of remaining equal values
+func (l *SkipList[T]) ResumeScan(cp Checkpoint[T]) *Scanner[T] {
+	s := &Scanner[T]{
+		list:       l,
+		generation: l.generation,
+		visited:    cp.Visited,
+	}
+	if cp.Visited == 0 {
+		// nothing had been visited when the
+		// checkpoint was created.
+		s.next = l.First()
+		return s
+	}
+	s.value = cp.Value
+	// step back from the first greater value over
+	// the equal values that had not been visited.
+	last := l.SearchDesc(cp.Value)
+	if last == nil {
+		s.next = l.First()
+		return s
+	}
+	s.next = last.Next()
+	for remaining := cp.Remaining; remaining > 0 && last != nil && !l.less(last.value, cp.Value); remaining-- {
+		s.next, last = last, last.Prev()
+	}
+	return s
+}
+
+// Advance the scanner to the next node.
+// Returns false when there are no more nodes
+// or the scan failed, see Err.
+func (s *Scanner[T]) Next() bool {
+	if s.list.failFast && s.generation != s.list.generation {
+		s.err = ErrConcurrentModification
+		s.node = nil
+		return false
+	}
+	if s.node = s.next; s.node == nil {
+		return false
+	}
+	s.list.visit()
+	s.value = s.node.value
+	s.next = s.node.Next()
+	s.visited++
+	return true
+}
+
+// Get the error that stopped the scanner, if any.
+func (s *Scanner[T]) Err() error {
+	return s.err
+}
+
+// Get the node the scanner is positioned at.
+// Returns nil if Next has not been called or
+// returned false.
+func (s *Scanner[T]) Node() *Node[T] {
+	return s.node
+}
+
+// Get the number of nodes visited so far and the
+// current length of the skiplist.
+func (s *Scanner[T]) Progress() (visited int, total int) {
+	return s.visited, s.list.Length()
+}
+
+// Capture the position of the scanner.
+// Complexity: O(k) where k is the number of remaining
+// values equal to the last value visited
+func (s *Scanner[T]) Checkpoint() Checkpoint[T] {
+	cp := Checkpoint[T]{
+		Value:   s.value,
+		Visited: s.visited,
+	}
+	if s.visited > 0 {
+		// the next node is still linked, unlike the
+		// last node visited which may be removed.
+		for node := s.next; node != nil && !s.list.less(s.value, node.value); node = node.Next() {
+			cp.Remaining++
+		}
+	}
+	return cp
+}
@@ -0,0 +1,96 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner(t *testing.T) {
+	type kv struct{ key, value int }
+	lessKey := func(a, b kv) bool { return a.key < b.key }
+	sortedData := make([]kv, 0, 1024)
+	for i := 0; len(sortedData) < cap(sortedData); i++ {
+		for j := 0; j <= i%4; j++ {
+			sortedData = append(sortedData, kv{key: i, value: j})
+		}
+	}
+	sl := skiplist.New(lessKey)
+	addAll(t, sl, sortedData)
+	// equal values are ordered by the skiplist, use
+	// the order of the nodes as the expected order.
+	for node, i := sl.First(), 0; node != nil; node, i = node.Next(), i+1 {
+		require.Equal(t, sortedData[i].key, node.Value().key)
+		sortedData[i] = node.Value()
+	}
+	t.Run("Progress", func(t *testing.T) {
+		s := sl.Scan()
+		require.Nil(t, s.Node())
+		for i := range sortedData {
+			require.True(t, s.Next())
+			require.Equal(t, sortedData[i], s.Node().Value())
+			visited, total := s.Progress()
+			require.Equal(t, i+1, visited)
+			require.Equal(t, len(sortedData), total)
+		}
+		require.False(t, s.Next())
+		require.Nil(t, s.Node())
+	})
+	t.Run("Checkpoint", func(t *testing.T) {
+		for stop := 0; stop <= len(sortedData); stop += 7 {
+			s := sl.Scan()
+			for i := 0; i < stop; i++ {
+				require.True(t, s.Next())
+			}
+			s = sl.ResumeScan(s.Checkpoint())
+			require.Nil(t, s.Node())
+			for i := stop; i < len(sortedData); i++ {
+				require.True(t, s.Next())
+				require.Equal(t, sortedData[i], s.Node().Value())
+				visited, _ := s.Progress()
+				require.Equal(t, i+1, visited)
+			}
+			require.False(t, s.Next())
+		}
+	})
+	t.Run("AddEqual", func(t *testing.T) {
+		sl := skiplist.New(lessKey)
+		addAll(t, sl, []kv{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {2, 0}})
+		s := sl.Scan()
+		visited := []kv{}
+		for i := 0; i < 3; i++ {
+			require.True(t, s.Next())
+			visited = append(visited, s.Node().Value())
+		}
+		cp := s.Checkpoint()
+		// equal values added after the checkpoint
+		// are added before the visited values.
+		addAll(t, sl, []kv{{1, 3}, {1, 4}})
+		s = sl.ResumeScan(cp)
+		for s.Next() {
+			visited = append(visited, s.Node().Value())
+		}
+		require.Len(t, visited, 5)
+		seen := map[kv]bool{}
+		for _, v := range visited {
+			require.False(t, seen[v], v)
+			seen[v] = true
+		}
+		require.False(t, seen[kv{1, 3}])
+		require.False(t, seen[kv{1, 4}])
+		require.Equal(t, kv{2, 0}, visited[4])
+	})
+	t.Run("RemoveCurrent", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		addAll(t, sl, []int{0, 1, 2, 3, 4})
+		s := sl.Scan()
+		values := []int{}
+		for s.Next() {
+			values = append(values, s.Node().Value())
+			require.NotNil(t, s.Node().RemoveFrom(sl))
+		}
+		require.Equal(t, []int{0, 1, 2, 3, 4}, values)
+		require.Equal(t, 0, sl.Length())
+	})
+}
@@ -0,0 +1,192 @@
+// Package shadowskiplist runs every operation against both
+// a skiplist and a simple reference implementation backed
+// by a sorted slice, reporting any divergence between the
+// two. It is meant to be enabled temporarily, such as in a
+// staging environment, to build confidence when adopting
+// new options of the skiplist.
+package shadowskiplist
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+// Reported when the skiplist and the reference
+// implementation disagree on the result of an operation.
+type DivergenceError struct {
+	// The operation that diverged.
+	Op string
+	// The result of the skiplist and of
+	// the reference implementation.
+	Got, Want any
+}
+
+func (e *DivergenceError) Error() string {
+	return fmt.Sprintf("shadowskiplist: %s diverged: got %v, want %v", e.Op, e.Got, e.Want)
+}
+
+// A SkipList performs every operation on both a skiplist
+// and a sorted slice, and reports divergences. Operations
+// on the reference are O(n). It is not threadsafe.
+type SkipList[T any] struct {
+	list   *skiplist.SkipList[T]
+	less   func(a, b T) bool
+	unique bool
+	ref    []T
+	report func(err error)
+}
+
+// Create a shadowed skiplist. If unique is set the skiplist
+// is created WithReplace and the reference keeps a single
+// value for every set of equal values. Divergences are
+// passed to report.
+func New[T any](
+	less func(a, b T) bool,
+	unique bool,
+	report func(err error),
+	opts ...skiplist.Option,
+) *SkipList[T] {
+	if unique {
+		opts = append(opts, skiplist.WithReplace())
+	}
+	return &SkipList[T]{
+		list:   skiplist.New(less, opts...),
+		less:   less,
+		unique: unique,
+		report: report,
+	}
+}
+
+// Get the shadowed skiplist. Modifying it directly
+// makes the reference diverge.
+func (l *SkipList[T]) List() *skiplist.SkipList[T] {
+	return l.list
+}
+
+// Returns the number of nodes in the skiplist.
+func (l *SkipList[T]) Length() int {
+	return l.list.Length()
+}
+
+// Insert a value, see skiplist.SkipList.Add.
+func (l *SkipList[T]) Add(value T) (node, replacedNode *skiplist.Node[T]) {
+	node, replacedNode = l.list.Add(value)
+	idx := l.search(value)
+	if l.unique && idx < len(l.ref) && !l.less(value, l.ref[idx]) {
+		l.checkValue("Add replaced", replacedNode, l.ref[idx], true)
+		l.ref[idx] = value
+	} else {
+		l.checkValue("Add replaced", replacedNode, value, false)
+		// equal values are inserted before
+		// existing equal values.
+		var zero T
+		l.ref = append(l.ref, zero)
+		copy(l.ref[idx+1:], l.ref[idx:])
+		l.ref[idx] = value
+	}
+	l.checkLength("Add")
+	return node, replacedNode
+}
+
+// Find the first node with a value greater than or equal
+// to the given value, see skiplist.SkipList.Search.
+func (l *SkipList[T]) Search(value T) *skiplist.Node[T] {
+	node := l.list.Search(value)
+	idx := l.search(value)
+	if idx < len(l.ref) {
+		l.checkValue("Search", node, l.ref[idx], true)
+	} else {
+		l.checkValue("Search", node, value, false)
+	}
+	return node
+}
+
+// Remove the first node with the given value,
+// see skiplist.SkipList.Remove.
+func (l *SkipList[T]) Remove(value T) *skiplist.Node[T] {
+	node := l.list.Remove(value)
+	idx := l.search(value)
+	if idx < len(l.ref) && !l.less(value, l.ref[idx]) {
+		l.checkValue("Remove", node, l.ref[idx], true)
+		l.ref = append(l.ref[:idx], l.ref[idx+1:]...)
+	} else {
+		l.checkValue("Remove", node, value, false)
+	}
+	l.checkLength("Remove")
+	return node
+}
+
+// Remove the first node, see skiplist.SkipList.RemoveFirst.
+func (l *SkipList[T]) RemoveFirst() *skiplist.Node[T] {
+	node := l.list.RemoveFirst()
+	if len(l.ref) > 0 {
+		l.checkValue("RemoveFirst", node, l.ref[0], true)
+		l.ref = l.ref[1:]
+	} else {
+		var zero T
+		l.checkValue("RemoveFirst", node, zero, false)
+	}
+	l.checkLength("RemoveFirst")
+	return node
+}
+
+// Compare every value of the skiplist with the reference,
+// reporting the first divergence. Returns false if they
+// diverge.
+// Complexity: O(n)
+func (l *SkipList[T]) Verify() bool {
+	if !l.checkLength("Verify") {
+		return false
+	}
+	idx := 0
+	for node := l.list.First(); node != nil; node = node.Next() {
+		if !l.checkValue("Verify", node, l.ref[idx], true) {
+			return false
+		}
+		idx++
+	}
+	return true
+}
+
+// Find the index of the first reference value
+// not less than the given value.
+func (l *SkipList[T]) search(value T) int {
+	return sort.Search(len(l.ref), func(i int) bool {
+		return !l.less(l.ref[i], value)
+	})
+}
+
+// Check that the node holds a value equal to the expected
+// value, or that the node is nil if no value is expected.
+func (l *SkipList[T]) checkValue(op string, node *skiplist.Node[T], want T, ok bool) bool {
+	switch {
+	case node == nil && !ok:
+		return true
+	case node == nil:
+		l.report(&DivergenceError{Op: op, Got: nil, Want: want})
+		return false
+	case !ok:
+		l.report(&DivergenceError{Op: op, Got: node.Value(), Want: nil})
+		return false
+	case l.less(node.Value(), want) || l.less(want, node.Value()):
+		l.report(&DivergenceError{Op: op, Got: node.Value(), Want: want})
+		return false
+	}
+	return true
+}
+
+// Check that the skiplist and the reference
+// hold the same number of values.
+func (l *SkipList[T]) checkLength(op string) bool {
+	if l.list.Length() != len(l.ref) {
+		l.report(&DivergenceError{
+			Op:   op + " length",
+			Got:  l.list.Length(),
+			Want: len(l.ref),
+		})
+		return false
+	}
+	return true
+}
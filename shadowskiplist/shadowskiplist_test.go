@@ -0,0 +1,43 @@
+package shadowskiplist_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/shadowskiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipList(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	for _, unique := range []bool{false, true} {
+		var errs []error
+		sl := shadowskiplist.New(less, unique, func(err error) { errs = append(errs, err) }, skiplist.WithRanks())
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 2000; i++ {
+			value := rng.Intn(100)
+			switch rng.Intn(4) {
+			case 0, 1:
+				sl.Add(value)
+			case 2:
+				sl.Remove(value)
+			case 3:
+				sl.Search(value)
+			}
+			if i%100 == 0 {
+				sl.RemoveFirst()
+			}
+		}
+		require.True(t, sl.Verify())
+		require.Empty(t, errs)
+
+		// modifying the skiplist directly diverges.
+		sl.List().Add(1000)
+		require.False(t, sl.Verify())
+		require.Len(t, errs, 1)
+		var divergence *shadowskiplist.DivergenceError
+		require.ErrorAs(t, errs[0], &divergence)
+		require.Equal(t, "Verify length", divergence.Op)
+	}
+}
@@ -1,7 +1,11 @@
 package skiplist
 
 import (
+	"io"
+	"log/slog"
 	"math/rand"
+	"strconv"
+	"time"
 )
 
 const MaxLevel = 32
@@ -18,26 +22,185 @@ func New[T any](
 	if o.rng == nil {
 		o.rng = rand.New(rand.NewSource(0)).Uint32
 	}
-	return &SkipList[T]{
-		lanes:   make([]*Node[T], MaxLevel),
-		less:    less,
-		replace: o.replace,
-		rng:     o.rng,
+	l := &SkipList[T]{
+		head: &Node[T]{
+			lanes: make([]*Node[T], MaxLevel),
+		},
+		tail: &Node[T]{
+			prevs: make([]*Node[T], MaxLevel),
+		},
+		less:       less,
+		replace:    o.replace,
+		failFast:   o.failFast,
+		detach:     o.detach,
+		ranks:      o.ranks,
+		counts:     o.counts,
+		versions:   o.versions,
+		timestamps: o.timestamps,
+		logger:     o.logger,
+		hardLimit:  o.hardLimit,
+		budget:     o.budget,
+		yieldEvery: o.yieldEvery,
+		appendOnly: o.appendOnly,
+		adaptive:   o.adaptive,
+		rng:        o.rng,
 	}
+	l.meta = l.ranks || l.counts || l.versions || l.timestamps
+	if o.weigh != nil {
+		weigh, ok := o.weigh.(func(T) int)
+		if !ok {
+			panic("skiplist: WithWeightLimit value type does not match the skiplist")
+		}
+		l.weigh = weigh
+		l.weightLimit = o.weightLimit
+		l.evictFrom = o.evictFrom
+		l.pressured = o.pressured
+		l.pressureFactor = o.pressureFactor
+	}
+	if o.bloomHash != nil {
+		hash, ok := o.bloomHash.(func(T) uint64)
+		if !ok {
+			panic("skiplist: WithBloomFilter value type does not match the skiplist")
+		}
+		l.bloom = &bloom[T]{hash: hash}
+	}
+	if o.alloc != nil {
+		alloc, ok := o.alloc.(Allocator[T])
+		if !ok {
+			panic("skiplist: WithAllocator value type does not match the skiplist")
+		}
+		l.alloc = alloc
+	}
+	if o.hotCache > 0 {
+		l.hot = &hotCache[T]{nodes: make([]*Node[T], o.hotCache)}
+	}
+	if l.counts {
+		// equal values are always collapsed
+		// into a single node.
+		l.replace = false
+	}
+	if l.ranks {
+		l.head.meta = &nodeMeta[T]{spans: make([]int, MaxLevel)}
+	}
+	l.Clear()
+	if o.recordTo != nil {
+		// set after clearing so that
+		// New is not recorded.
+		encode, ok := o.recordEncode.(func(T) string)
+		if !ok {
+			panic("skiplist: WithRecorder value type does not match the skiplist")
+		}
+		l.recorder = &recorder[T]{w: o.recordTo, encode: encode}
+	}
+	return l
 }
 
 type options struct {
-	rng     func() uint32
-	replace bool
+	rng        func() uint32
+	replace    bool
+	failFast   bool
+	detach     bool
+	ranks      bool
+	counts     bool
+	versions   bool
+	timestamps bool
+	// A func(T) int, the type parameter
+	// is not known to the options.
+	weigh          any
+	weightLimit    int
+	evictFrom      End
+	pressured      func() bool
+	pressureFactor float64
+	// A func(T) uint64, the type parameter
+	// is not known to the options.
+	bloomHash any
+	// An Allocator[T], the type parameter
+	// is not known to the options.
+	alloc    any
+	adaptive bool
+	budget   int
+	recordTo io.Writer
+	// A func(T) string, the type parameter
+	// is not known to the options.
+	recordEncode any
+	logger       *slog.Logger
+	hardLimit    int
+	hotCache     int
+	yieldEvery   int
+	appendOnly   bool
 }
 
 type SkipList[T any] struct {
-	less    func(a, b T) bool
-	lanes   []*Node[T]
-	last    *Node[T]
+	less func(a, b T) bool
+	// Sentinel node preceeding the first node.
+	// It has a forward lane for every level
+	// but no backward lanes.
+	head *Node[T]
+	// Sentinel node succeeding the last node.
+	// It has a backward lane for every level
+	// but no forward lanes.
+	tail    *Node[T]
 	length  int
 	replace bool
-	rng     func() uint32
+	// Incremented for every structural modification.
+	generation uint64
+	failFast   bool
+	detach     bool
+	// Maintain the spans of the lanes.
+	ranks      bool
+	counts     bool
+	versions   bool
+	timestamps bool
+	// The oldest and newest node in insertion
+	// order WithTimestamps.
+	oldest *Node[T]
+	newest *Node[T]
+	// A node being moved by an update, which keeps
+	// its position in the insertion order.
+	moving *Node[T]
+	// The last version stamped on a node.
+	version uint64
+	// Allocate optional node data.
+	meta bool
+	// Total weight of all values WithWeightLimit.
+	weight      int
+	weigh       func(T) int
+	weightLimit int
+	evictFrom   End
+	// Scales the weight limit while pressured,
+	// WithMemoryPressure.
+	pressured      func() bool
+	pressureFactor float64
+	watchers       []*watcher[T]
+	// Bloom filter of the values WithBloomFilter.
+	bloom *bloom[T]
+	alloc Allocator[T]
+	// Records operations WithRecorder.
+	recorder *recorder[T]
+	// Evictions and rebuilds are made as
+	// recorded while replaying.
+	replaying bool
+	// Keep track of the number of nodes of
+	// each level WithAdaptiveLevels.
+	adaptive bool
+	levels   [MaxLevel]int
+	// Modifications since the levels were checked.
+	adaptOps int
+	// The maximum number of comparisons of a
+	// search WithComparisonBudget.
+	budget int
+	// The maximum number of nodes WithHardLimit.
+	hardLimit int
+	// Recently found nodes WithHotCache.
+	hot *hotCache[T]
+	// Nodes visited since the processor
+	// was last yielded WithYieldEvery.
+	yieldEvery int
+	visits     int
+	// Values are added in ascending order.
+	appendOnly bool
+	logger     *slog.Logger
+	rng        func() uint32
 }
 
 // Returns the number of nodes in the skiplist.
@@ -48,96 +211,165 @@ func (l *SkipList[T]) Length() int {
 // Clear the contents of the skiplist, setting
 // its length to 0.
 func (l *SkipList[T]) Clear() {
-	for i := range l.lanes {
-		l.lanes[i] = nil
+	l.recordOp("clear")
+	for i := range l.head.lanes {
+		l.head.lanes[i] = l.tail
+		l.tail.prevs[i] = l.head
+		if l.ranks {
+			l.head.meta.spans[i] = 1
+		}
+	}
+	if l.hot != nil {
+		l.hot.reset()
 	}
-	l.last = nil
+	l.oldest, l.newest = nil, nil
 	l.length = 0
+	l.weight = 0
+	l.levels = [MaxLevel]int{}
+	if l.bloom != nil {
+		l.bloom.reset(0)
+	}
+	l.generation++
 }
 
 // Get the first node in the skiplist.
 // Returns nil if the skiplist is empty.
 // Complexity: O(1)
 func (l *SkipList[T]) First() *Node[T] {
-	return l.lanes[0]
+	return l.head.Next()
 }
 
 // Get the last node in the skiplist.
 // Returns nil if the skiplist is empty.
 // Complexity: O(1)
 func (l *SkipList[T]) Last() *Node[T] {
-	return l.last
+	return l.tail.Prev()
+}
+
+// Get the first value in the skiplist.
+// Returns false if the skiplist is empty.
+// Complexity: O(1)
+func (l *SkipList[T]) FirstValue() (value T, ok bool) {
+	return l.First().valueOk()
+}
+
+// Get the last value in the skiplist.
+// Returns false if the skiplist is empty.
+// Complexity: O(1)
+func (l *SkipList[T]) LastValue() (value T, ok bool) {
+	return l.Last().valueOk()
 }
 
 // Insert a value into the skiplist and return its node.
-// Average complexity: O(log(n))
+// Returns a nil node if the skiplist is full WithHardLimit.
+// Average complexity: O(log(n)), O(1) WithAppendOnly
+// for values greater than the last value
 func (l *SkipList[T]) Add(value T) (node *Node[T], replacedNode *Node[T]) {
-	level := 1
-	// add geometric distribution sample in range [0, 31]
-	for i := (^uint32(0) >> 1) & l.rng(); i&1 == 1; i >>= 1 {
-		level++
-	}
-	node = &Node[T]{
-		value: value,
-		lanes: make([]*Node[T], level),
-	}
+	l.record("add", value)
+	return l.add(value)
+}
 
-	lanes := l.lanes
-	if l.replace {
-		for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
-			for ; lanes[levelIdx] != nil && l.less(lanes[levelIdx].value, value); lanes = lanes[levelIdx].lanes {
-			}
-			if lanes[levelIdx] != nil && !l.less(value, lanes[levelIdx].value) {
-				replacedNode = lanes[levelIdx]
-				// route around existing node, removing
-				// any references to it for the current lane.
-				node.prev = lanes[levelIdx].prev
-				lanes[levelIdx] = lanes[levelIdx].lanes[levelIdx]
-			}
-			if levelIdx < level {
-				node.lanes[levelIdx] = lanes[levelIdx]
-				lanes[levelIdx] = node
-				if levelIdx == 0 && node.lanes[0] != nil {
-					if replacedNode == nil {
-						// prev for the new node has
-						// not been set yet.
-						node.prev = node.lanes[0].prev
-					}
-					// prev for the next node should
-					// point back to the new node.
-					node.lanes[0].prev = node
-				}
-			}
+// Insert a value like Add without recording it.
+func (l *SkipList[T]) add(value T) (node *Node[T], replacedNode *Node[T]) {
+	if l.appendOnly {
+		if node, ok := l.appendLast(value); ok {
+			return node, nil
 		}
-	} else {
-		for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
-			for ; lanes[levelIdx] != nil && l.less(lanes[levelIdx].value, value); lanes = lanes[levelIdx].lanes {
-			}
-			if levelIdx >= level {
-				continue
-			}
-			node.lanes[levelIdx] = lanes[levelIdx]
-			lanes[levelIdx] = node
-			if levelIdx == 0 && node.lanes[0] != nil {
-				// prev for the new node has
-				// not been set yet.
-				node.prev = node.lanes[0].prev
-				// prev for the next node should
-				// point back to the new node.
-				node.lanes[0].prev = node
-			}
+		if l.less(value, l.tail.prevs[0].value) {
+			l.warn("skiplist: value added out of order WithAppendOnly", slog.Any("value", value))
 		}
 	}
-	if replacedNode == nil {
-		l.length++
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(value, &preds)
+	if l.counts {
+		if node = l.equalSucc(value, &preds); node != nil {
+			l.addOccurrences(node, 1, l.weighOf(node.value))
+			return node, nil
+		}
 	}
-	if l.last == nil || l.less(l.last.value, value) {
-		node.prev = l.last
-		l.last = node
+	if l.full(value, &preds) {
+		return nil, nil
 	}
+	calls := l.callOptions(value)
+	node = l.newNode(value, l.randomLevel())
+	replacedNode = l.insert(node, &preds, calls)
+	l.afterInsert(node)
 	return node, replacedNode
 }
 
+// Insert a value into the skiplist like Add, but return
+// an error instead of panicking if the comparator panics,
+// or ErrFull if the skiplist is full WithHardLimit.
+// Panics of the functions given to other options, such as
+// the weight function of WithWeightLimit, are returned as
+// well. The skiplist is left unmodified if an error is
+// returned before the value is inserted, which is always
+// the case for ErrFull and for panics while searching,
+// such as when a search exceeds WithComparisonBudget.
+// Panics of calls made once the value is inserted, such
+// as comparisons notifying the watchers of Watch or the
+// weight function evicting values, are returned with the
+// value inserted.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) TryAdd(value T) (node *Node[T], replacedNode *Node[T], err error) {
+	defer l.recoverPanic(&err)
+	if node, replacedNode = l.Add(value); node == nil {
+		return nil, nil, ErrFull
+	}
+	return node, replacedNode, nil
+}
+
+// Create a node that can be inserted with AddNode.
+// A level less than 1 is replaced by a random level
+// drawn the same way as for nodes created by Add.
+// Panics if the level is greater than MaxLevel.
+func (l *SkipList[T]) NewNode(value T, level int) *Node[T] {
+	if level < 1 {
+		l.record("newnode", value)
+		level = l.randomLevel()
+	} else if level > MaxLevel {
+		panic("skiplist: node level out of range")
+	}
+	return l.newNode(value, level)
+}
+
+// Insert a node created by NewNode into the skiplist.
+// The node must not be part of any skiplist, see
+// Node.Reset for reusing removed nodes.
+// Returns the node that was replaced, if any.
+// WithCounts, if a node with an equal value exists the
+// count of the given node is added to the existing node,
+// which is returned instead of inserting the given node.
+// The node is not inserted if the skiplist is full
+// WithHardLimit.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) AddNode(node *Node[T]) (replacedNode *Node[T]) {
+	l.record("addnode", node.value, strconv.Itoa(len(node.lanes)), strconv.Itoa(node.Count()))
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(node.value, &preds)
+	return l.addNode(node, &preds)
+}
+
+// Insert the node directly after the given predecessors
+// like AddNode.
+func (l *SkipList[T]) addNode(
+	node *Node[T],
+	preds *[MaxLevel]*Node[T],
+) (replacedNode *Node[T]) {
+	if l.counts {
+		if existing := l.equalSucc(node.value, preds); existing != nil {
+			l.addOccurrences(existing, node.Count(), l.weighOf(existing.value))
+			return existing
+		}
+	}
+	if l.full(node.value, preds) {
+		return nil
+	}
+	replacedNode = l.insert(node, preds, l.callOptions(node.value))
+	l.afterInsert(node)
+	return replacedNode
+}
+
 // Find and return the first node with a value that is
 // greater or equal to the given value.
 // Returns nil if no such node exists.
@@ -145,12 +377,84 @@ func (l *SkipList[T]) Add(value T) (node *Node[T], replacedNode *Node[T]) {
 func (l *SkipList[T]) Search(
 	value T,
 ) (node *Node[T]) {
-	lanes := l.lanes
+	if l.hot != nil {
+		if node = l.cached(value); node != nil {
+			return node
+		}
+	}
+	if l.appendOnly {
+		if last := l.tail.prevs[0]; last == l.head || l.less(last.value, value) {
+			return nil
+		}
+	}
+	comparisons := 0
+	pred := l.head
 	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
-		for ; lanes[levelIdx] != nil && l.less(lanes[levelIdx].value, value); lanes = lanes[levelIdx].lanes {
+		for ; pred.lanes[levelIdx] != l.tail && l.searchLess(&comparisons, pred.lanes[levelIdx].value, value); pred = pred.lanes[levelIdx] {
 		}
 	}
-	return lanes[0]
+	node = pred.Next()
+	if l.hot != nil && node != nil && !l.less(value, node.value) {
+		l.cache(node)
+	}
+	return node
+}
+
+// Find and return the first value that is greater
+// or equal to the given value.
+// Returns false if no such value exists.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) SearchValue(value T) (T, bool) {
+	return l.Search(value).valueOk()
+}
+
+// Find and return the last node with a value that is
+// less than or equal to the given value. The search
+// starts from the end of the skiplist and works backward.
+// Returns nil if no such node exists.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) SearchDesc(
+	value T,
+) (node *Node[T]) {
+	comparisons := 0
+	succ := l.tail
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		for ; succ.prevs[levelIdx] != l.head && l.searchLess(&comparisons, value, succ.prevs[levelIdx].value); succ = succ.prevs[levelIdx] {
+		}
+	}
+	return succ.Prev()
+}
+
+// Find both the first node with a value greater than or
+// equal to min and the last node with a value less than
+// or equal to max in a single search, sharing the path of
+// the search for as long as the two nodes are not split
+// by a lane.
+// Returns nil nodes if no value is in the range [min, max].
+// Average complexity: O(log(n))
+func (l *SkipList[T]) RangeBounds(min, max T) (first, last *Node[T]) {
+	if l.less(max, min) {
+		return nil, nil
+	}
+	comparisons := 0
+	// the last nodes less than min and
+	// less than or equal to max.
+	lo, hi := l.head, l.head
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		shared := lo == hi
+		for ; lo.lanes[levelIdx] != l.tail && l.searchLess(&comparisons, lo.lanes[levelIdx].value, min); lo = lo.lanes[levelIdx] {
+		}
+		if shared {
+			// nodes less than min are less than max.
+			hi = lo
+		}
+		for ; hi.lanes[levelIdx] != l.tail && !l.searchLess(&comparisons, max, hi.lanes[levelIdx].value); hi = hi.lanes[levelIdx] {
+		}
+	}
+	if lo == hi {
+		return nil, nil
+	}
+	return lo.lanes[0], hi
 }
 
 // Remove the first node encountered for a given value
@@ -160,57 +464,243 @@ func (l *SkipList[T]) Search(
 func (l *SkipList[T]) Remove(
 	value T,
 ) (node *Node[T]) {
-	lanes := l.lanes
-	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
-		for ; lanes[levelIdx] != nil && l.less(lanes[levelIdx].value, value); lanes = lanes[levelIdx].lanes {
-		}
-		if lanes[levelIdx] != nil && !l.less(value, lanes[levelIdx].value) {
-			// grab the node being removed
-			node = lanes[levelIdx]
-			// route forward lane to the node succeeding
-			// the node being removed for the current level.
-			lanes[levelIdx] = lanes[levelIdx].lanes[levelIdx]
+	if l.hot != nil {
+		if node = l.cached(value); node != nil {
+			l.recordNode("remove", node)
+			l.release(node)
+			return node
 		}
 	}
-
-	if node == nil {
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(value, &preds)
+	if node = l.equalSucc(value, &preds); node == nil {
 		// node with given value was not found, return nothing
 		return nil
 	}
-	l.length--
-	if node.lanes[0] == nil {
-		l.last = node.prev
-	} else {
-		// route backward lane to the node preceeding
-		// the node being removed.
-		node.lanes[0].prev = node.prev
+	if l.hot != nil && l.counts && node.meta.dups > 0 {
+		// the node remains in the skiplist
+		l.cache(node)
 	}
+	l.recordNode("remove", node)
+	l.release(node)
 	return node
 }
 
+// Remove the first node encountered for a given value
+// like Remove, but return an error instead of panicking
+// if the comparator panics. Panics of the functions given
+// to other options, such as the weight function of
+// WithWeightLimit, are returned as well.
+// The skiplist is left unmodified if an error is returned.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) TryRemove(value T) (node *Node[T], err error) {
+	defer l.recoverPanic(&err)
+	return l.Remove(value), nil
+}
+
 // Remove the first node in the sorted collection and
 // return it.
 // Returns nil if the collection is empty.
 // Complexity: O(1)
 func (l *SkipList[T]) RemoveFirst() (node *Node[T]) {
-	if node = l.lanes[0]; node == nil {
+	l.recordOp("removefirst")
+	if node = l.head.lanes[0]; node == l.tail {
 		return nil
 	}
-	// route the forward lanes around the node
-	// being removed.
-	for levelIdx := range l.lanes {
-		if l.lanes[levelIdx] == node {
-			l.lanes[levelIdx] = node.lanes[levelIdx]
+	l.release(node)
+	return node
+}
+
+// Remove the last node in the sorted collection and
+// return it.
+// Returns nil if the collection is empty.
+// Complexity: O(1)
+func (l *SkipList[T]) RemoveLast() (node *Node[T]) {
+	l.recordOp("removelast")
+	if node = l.tail.prevs[0]; node == l.head {
+		return nil
+	}
+	l.release(node)
+	return node
+}
+
+// Draw a random node level in the range [1, 32]
+// from a geometric distribution.
+func (l *SkipList[T]) randomLevel() int {
+	level := 1
+	for i := (^uint32(0) >> 1) & l.rng(); i&1 == 1; i >>= 1 {
+		level++
+	}
+	return level
+}
+
+// Find the last node with a value less than the given
+// value for every level and store it in preds.
+func (l *SkipList[T]) searchPreds(
+	value T,
+	preds *[MaxLevel]*Node[T],
+) {
+	comparisons := 0
+	pred := l.head
+	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
+		for ; pred.lanes[levelIdx] != l.tail && l.searchLess(&comparisons, pred.lanes[levelIdx].value, value); pred = pred.lanes[levelIdx] {
 		}
+		preds[levelIdx] = pred
+	}
+}
+
+// Get the node directly succeeding the predecessors
+// if its value is equal to the given value.
+func (l *SkipList[T]) equalSucc(
+	value T,
+	preds *[MaxLevel]*Node[T],
+) *Node[T] {
+	if next := preds[0].lanes[0]; next != l.tail && !l.less(value, next.value) {
+		return next
+	}
+	return nil
+}
+
+// Insert the node directly after the given predecessors,
+// replacing any node with an equal value WithReplace.
+// Returns the node that was replaced, if any.
+func (l *SkipList[T]) insert(
+	node *Node[T],
+	preds *[MaxLevel]*Node[T],
+	calls optionCalls,
+) (replacedNode *Node[T]) {
+	l.checkOrder(node.value, preds)
+	if l.replace {
+		replacedNode = l.equalSucc(node.value, preds)
+	}
+	l.link(node, preds, calls)
+	if replacedNode != nil {
+		// values are unique so the node is linked
+		// directly before the replaced node.
+		l.unlink(replacedNode)
+	}
+	return replacedNode
+}
+
+// The results of the functions given to options for a
+// value about to be stored in a node. The functions are
+// called before the skiplist is modified, so that a
+// panic leaves the skiplist unmodified.
+type optionCalls struct {
+	// The weight of a single occurrence
+	// WithWeightLimit.
+	weight int
+	// The hash WithBloomFilter.
+	hash uint64
+}
+
+// Call the functions given to options for a value.
+func (l *SkipList[T]) callOptions(value T) (calls optionCalls) {
+	calls.weight = l.weighOf(value)
+	if l.bloom != nil {
+		calls.hash = l.bloom.hash(value)
+	}
+	return calls
+}
+
+// Insert the node directly after the given
+// predecessors for each of its levels, given the
+// results of calling the functions of the options
+// for its value.
+func (l *SkipList[T]) link(
+	node *Node[T],
+	preds *[MaxLevel]*Node[T],
+	calls optionCalls,
+) {
+	if l.bloom != nil {
+		// rebuilding calls the hash function.
+		l.addBloom(calls.hash)
+	}
+	l.weight += calls.weight * node.Count()
+	if l.meta && node.meta == nil {
+		// node was created by another skiplist
+		node.meta = &nodeMeta[T]{}
+	}
+	if l.timestamps && node.meta.times == nil {
+		node.meta.times = &nodeTimes[T]{}
+	}
+	if l.ranks {
+		l.linkSpans(node, preds)
+	}
+	if l.versions {
+		l.stamp(node)
+	}
+	if l.timestamps {
+		l.linkTime(node)
+	}
+	for levelIdx, pred := range preds[:len(node.lanes)] {
+		next := pred.lanes[levelIdx]
+		node.lanes[levelIdx] = next
+		node.prevs[levelIdx] = pred
+		pred.lanes[levelIdx] = node
+		next.prevs[levelIdx] = node
+	}
+	if l.adaptive {
+		l.levels[len(node.lanes)-1]++
+	}
+	l.length++
+	l.generation++
+}
+
+// Route the forward and backward lanes of the
+// neighbouring nodes around the node for each
+// of its levels.
+func (l *SkipList[T]) unlink(node *Node[T]) {
+	l.unlinkWeighed(node, l.weighOf(node.value))
+}
+
+// Unlink the node given the weight of
+// a single occurrence of its value.
+func (l *SkipList[T]) unlinkWeighed(node *Node[T], weight int) {
+	l.weight -= weight * node.Count()
+	if l.ranks {
+		l.unlinkSpans(node)
+	}
+	for levelIdx, next := range node.lanes {
+		prev := node.prevs[levelIdx]
+		prev.lanes[levelIdx] = next
+		next.prevs[levelIdx] = prev
+		if l.detach {
+			node.lanes[levelIdx] = nil
+			node.prevs[levelIdx] = nil
+		}
+	}
+	if l.adaptive {
+		l.levels[len(node.lanes)-1]--
+	}
+	if l.hot != nil {
+		l.hot.evict(node)
+	}
+	if l.timestamps && node != l.moving {
+		l.unchain(node)
 	}
 	l.length--
-	if l.length == 0 {
-		l.last = nil
-	} else if node.lanes[0] != nil {
-		// we know that no previous node exists
-		// for the new first node in the list as
-		// we just removed its preceeding node.
-		node.lanes[0].prev = nil
+	l.generation++
+}
+
+// Create a node with forward and backward lanes
+// for the given number of levels.
+func (l *SkipList[T]) newNode(value T, level int) *Node[T] {
+	var node *Node[T]
+	if l.alloc != nil {
+		node = l.alloc.AllocNode(level)
+	} else {
+		node = allocNode[T](level)
+	}
+	node.value = value
+	if l.meta && node.meta == nil {
+		node.meta = &nodeMeta[T]{}
+	}
+	if l.timestamps && node.meta.times == nil {
+		node.meta.times = &nodeTimes[T]{}
+	}
+	if l.ranks && len(node.meta.spans) != level {
+		node.meta.spans = make([]int, level)
 	}
 	return node
 }
@@ -219,9 +709,37 @@ type Node[T any] struct {
 	value T
 	// The next node and any optional skiplanes.
 	lanes []*Node[T]
-	// The node directly preceeding this node
-	// in the list.
-	prev *Node[T]
+	// The previous node and any optional skiplanes,
+	// mirroring the forward lanes.
+	prevs []*Node[T]
+	// Only allocated when enabled by an option, so
+	// that a skiplist without options does not pay
+	// for the data of the options.
+	meta *nodeMeta[T]
+}
+
+// Optional data of a node.
+type nodeMeta[T any] struct {
+	// The number of nodes skipped by each forward
+	// lane, plus one. Only maintained WithRanks.
+	spans []int
+	// The number of additional occurrences of
+	// the value of the node WithCounts.
+	dups int
+	// Stamped on insertion and update WithVersions.
+	version uint64
+	// Only allocated WithTimestamps.
+	times *nodeTimes[T]
+}
+
+// Data of a node WithTimestamps.
+type nodeTimes[T any] struct {
+	inserted time.Time
+	updated  time.Time
+	// The nodes inserted before and
+	// after the node.
+	older *Node[T]
+	newer *Node[T]
 }
 
 // Get the value of the node.
@@ -229,14 +747,55 @@ func (n *Node[T]) Value() T {
 	return n.value
 }
 
+// Get the value of the node, or the zero
+// value and false if the node is nil.
+func (n *Node[T]) valueOk() (value T, ok bool) {
+	if n == nil {
+		return value, false
+	}
+	return n.value, true
+}
+
 // Get the next node.
+// Returns nil for the last node and
+// for detached nodes.
 func (n *Node[T]) Next() *Node[T] {
-	return n.lanes[0]
+	// the tail sentinel is the only node without lanes.
+	if next := n.lanes[0]; next != nil && len(next.lanes) != 0 {
+		return next
+	}
+	return nil
 }
 
 // Get the previous node.
+// Returns nil for the first node and
+// for detached nodes.
 func (n *Node[T]) Prev() *Node[T] {
-	return n.prev
+	// the head sentinel is the only node
+	// without backward lanes.
+	if prev := n.prevs[0]; prev != nil && len(prev.prevs) != 0 {
+		return prev
+	}
+	return nil
+}
+
+// Set the value of a node that has been removed from
+// its skiplist and clear its lanes, so that the node
+// can be inserted again with AddNode.
+// The level of the node is kept.
+func (n *Node[T]) Reset(value T) {
+	n.value = value
+	for levelIdx := range n.lanes {
+		n.lanes[levelIdx] = nil
+		n.prevs[levelIdx] = nil
+	}
+	if n.meta != nil {
+		// the spans are set when the node is linked.
+		*n.meta = nodeMeta[T]{spans: n.meta.spans, times: n.meta.times}
+		if n.meta.times != nil {
+			*n.meta.times = nodeTimes[T]{}
+		}
+	}
 }
 
 // Get the node level.
@@ -248,58 +807,32 @@ func (n *Node[T]) Level() int {
 // Remove any occurence of this node in the given skiplist.
 // Returns itself if the node was found, else nil.
 // Average complexity: O(log(n))
-// If this is the first node in the skiplist its removal
-// operation has a complexity of O(1).
+// If this is the first or last node in the skiplist its
+// removal operation has a complexity of O(1).
 func (n *Node[T]) RemoveFrom(
 	l *SkipList[T],
 ) (node *Node[T]) {
 	if n == nil {
 		return
 	}
-	lanes := l.lanes
-	if lanes[0] == n {
-		return l.RemoveFirst()
+	if l.head.lanes[0] == n || l.tail.prevs[0] == n {
+		l.recordNode("unlink", n)
+		l.unlink(n)
+		return n
 	}
-	for levelIdx := MaxLevel - 1; levelIdx >= 0; levelIdx-- {
-		for ; lanes[levelIdx] != nil && l.less(lanes[levelIdx].value, n.value); lanes = lanes[levelIdx].lanes {
-		}
-		if !l.replace {
-			// There may be more nodes that match the value
-			// of the node being removed. The nodes are traversed
-			// while node values are equal to the value of the node
-			// being removed. Stops on node match.
-			//
-			// Revert to the current node if there
-			// are no node matches.
-			currentLanes := lanes
-			for ; lanes[levelIdx] != nil && !l.less(n.value, lanes[levelIdx].value) && lanes[levelIdx] != n; lanes = lanes[levelIdx].lanes {
-			}
-			if lanes[levelIdx] != n {
-				lanes = currentLanes
-			}
-		}
-		if lanes[levelIdx] == n {
-			// grab the node being removed
-			node = lanes[levelIdx]
-			// route forward lane to the node succeeding
-			// the node being removed for the current level.
-			lanes[levelIdx] = lanes[levelIdx].lanes[levelIdx]
+	// There may be more nodes that match the value of the
+	// node being removed. The nodes are traversed while node
+	// values are equal to the value of the node being removed.
+	for node = l.Search(n.value); node != nil && !l.less(n.value, node.value); node = node.Next() {
+		if node == n {
+			l.recordNode("unlink", n)
+			l.unlink(n)
+			return n
 		}
 	}
-
-	if node == nil {
-		// node was not found, return nothing
-		return
-	}
-	l.length--
-	if node.lanes[0] == nil {
-		l.last = node.prev
-	} else {
-		// route backward lane to the node preceeding
-		// the node being removed.
-		node.lanes[0].prev = node.prev
-	}
-	return
+	// node was not found, return nothing
+	l.warn("skiplist: removed node not found in skiplist", slog.Any("value", n.value))
+	return nil
 }
 
 type Option interface {
@@ -334,3 +867,34 @@ func (o *withReplace) apply(opts *options) {
 func WithReplace() Option {
 	return &withReplace{}
 }
+
+var _ Option = (*withFailFast)(nil)
+
+type withFailFast struct{}
+
+func (o *withFailFast) apply(opts *options) {
+	opts.failFast = true
+}
+
+// Make iterators fail with ErrConcurrentModification
+// when used after the skiplist has been modified.
+func WithFailFast() Option {
+	return &withFailFast{}
+}
+
+var _ Option = (*withDetachOnRemove)(nil)
+
+type withDetachOnRemove struct{}
+
+func (o *withDetachOnRemove) apply(opts *options) {
+	opts.detach = true
+}
+
+// Detach nodes when they are removed from the skiplist
+// by clearing their forward and backward lanes.
+// A detached node has neither a next nor a previous node,
+// so it can not be used to walk back into the skiplist
+// and does not keep its former neighbours reachable.
+func WithDetachOnRemove() Option {
+	return &withDetachOnRemove{}
+}
@@ -1,11 +1,13 @@
 package skiplist_test
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"testing"
 
 	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/gen"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/constraints"
 )
@@ -169,6 +171,203 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestComparatorPanic(t *testing.T) {
+	sortedData := make([]int, 1024)
+	for i := range sortedData {
+		sortedData[i] = 2 * i
+	}
+	errBroken := errors.New("broken comparator")
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithReplace()}} {
+		panicAfter := -1
+		lessOrPanic := func(a, b int) bool {
+			if panicAfter == 0 {
+				panic(errBroken)
+			}
+			panicAfter--
+			return a < b
+		}
+		sl := skiplist.New(lessOrPanic, opts...)
+		addAll(t, sl, sortedData)
+		// panic after a varying number of comparisons
+		for i := 0; i < 8; i++ {
+			panicAfter = i
+			node, replaced, err := sl.TryAdd(2*i*i + 1)
+			require.Nil(t, node)
+			require.Nil(t, replaced)
+			var panicErr *skiplist.PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.ErrorIs(t, err, errBroken)
+			panicAfter = i
+			node, err = sl.TryRemove(2 * i * i)
+			require.Nil(t, node)
+			require.ErrorIs(t, err, errBroken)
+			panicAfter = -1
+			requireEqual(t, sl, sortedData)
+		}
+		node, replaced, err := sl.TryAdd(1)
+		require.NoError(t, err)
+		require.NotNil(t, node)
+		require.Nil(t, replaced)
+		node, err = sl.TryRemove(1)
+		require.NoError(t, err)
+		require.NotNil(t, node)
+		requireEqual(t, sl, sortedData)
+	}
+}
+
+func TestOptionPanic(t *testing.T) {
+	errBroken := errors.New("broken option")
+	weigh := func(v int) int {
+		if v < 0 {
+			panic(errBroken)
+		}
+		return 1
+	}
+	hash := func(v int) uint64 {
+		if v < 0 {
+			panic(errBroken)
+		}
+		return uint64(v) * 0x9e3779b97f4a7c15
+	}
+	// each of the functions panics on its own.
+	for _, broken := range [][]skiplist.Option{
+		{
+			skiplist.WithWeightLimit(weigh, 1000, skiplist.Back),
+			skiplist.WithBloomFilter(func(v int) uint64 { return uint64(v) }),
+		},
+		{
+			skiplist.WithWeightLimit(func(int) int { return 1 }, 1000, skiplist.Back),
+			skiplist.WithBloomFilter(hash),
+		},
+	} {
+		for _, opts := range [][]skiplist.Option{nil, {skiplist.WithReplace()}, {skiplist.WithCounts()}} {
+			sl := skiplist.New(less[int], append(append([]skiplist.Option{
+				skiplist.WithRanks(),
+			}, broken...), opts...)...)
+			data := []int{1, 2, 3, 5, 8}
+			addAll(t, sl, data)
+			requireUnmodified := func() {
+				requireEqual(t, sl, data)
+				require.Equal(t, len(data), sl.Length())
+				require.Equal(t, len(data), sl.Weight())
+				require.NoError(t, sl.CheckStructure())
+			}
+			// the functions are called before
+			// the skiplist is modified.
+			_, _, err := sl.TryAdd(-1)
+			require.ErrorIs(t, err, errBroken)
+			var panicErr *skiplist.PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.NotContains(t, err.Error(), "comparator")
+			requireUnmodified()
+			// in place and moving the node.
+			for _, node := range []*skiplist.Node[int]{sl.First(), sl.Last()} {
+				require.PanicsWithError(t, errBroken.Error(), func() {
+					sl.CompareAndUpdate(node, node.Value(), -1, func(a, b int) bool { return a == b })
+				})
+				requireUnmodified()
+			}
+		}
+	}
+}
+
+// Keys of the distributions the benchmarks are run with,
+// as the distribution changes the performance considerably.
+func benchmarkKeys(n int) []struct {
+	name string
+	keys []int
+} {
+	distributions := []struct {
+		name string
+		seq  func(yield func(int) bool)
+	}{
+		{"Uniform", gen.Uniform(1, n, n)},
+		{"Zipfian", gen.Zipfian(1, n, 1.1, n)},
+		{"Sequential", gen.Sequential(1, n, 10)},
+		{"NearSorted", gen.NearSorted(1, n, 0.01)},
+	}
+	keys := make([]struct {
+		name string
+		keys []int
+	}, len(distributions))
+	for i, dist := range distributions {
+		keys[i].name = dist.name
+		dist.seq(func(key int) bool {
+			keys[i].keys = append(keys[i].keys, key)
+			return true
+		})
+	}
+	return keys
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for _, dist := range benchmarkKeys(10000) {
+		b.Run(dist.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sl := skiplist.New(less[int])
+				for _, key := range dist.keys {
+					sl.Add(key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	for _, dist := range benchmarkKeys(10000) {
+		sl := skiplist.New(less[int])
+		for _, key := range dist.keys {
+			sl.Add(key)
+		}
+		b.Run(dist.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sl.Search(dist.keys[i%len(dist.keys)])
+			}
+		})
+	}
+}
+
+func TestAddNode(t *testing.T) {
+	const numElem = 1 << 12
+	sortedData := [numElem]int{}
+	for i := 0; i < numElem; i++ {
+		sortedData[i] = i
+	}
+	sl := skiplist.New(less[int])
+	pool := make([]*skiplist.Node[int], 0, numElem)
+	for i := range sortedData {
+		node := sl.NewNode(0, i%8)
+		if i%8 == 0 {
+			require.GreaterOrEqual(t, node.Level(), 1)
+		} else {
+			require.Equal(t, i%8, node.Level())
+		}
+		pool = append(pool, node)
+	}
+	require.Panics(t, func() { sl.NewNode(0, skiplist.MaxLevel+1) })
+	for round := 0; round < 2; round++ {
+		for i := range sortedData {
+			node := pool[i]
+			node.Reset(sortedData[len(sortedData)-1-i])
+			require.Nil(t, sl.AddNode(node))
+		}
+		requireEqual(t, sl, sortedData[:])
+		pool = pool[:0]
+		for node := sl.RemoveFirst(); node != nil; node = sl.RemoveFirst() {
+			pool = append(pool, node)
+		}
+		require.Len(t, pool, numElem)
+	}
+	t.Run("WithReplace", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithReplace())
+		node := sl.NewNode(1, 4)
+		require.Nil(t, sl.AddNode(node))
+		replaced := sl.AddNode(sl.NewNode(1, 2))
+		require.Equal(t, node, replaced)
+		requireEqual(t, sl, []int{1})
+	})
+}
+
 func TestRemoveFrom(t *testing.T) {
 	const numElem = 1 << 16
 	sortedData := [numElem]int{}
@@ -280,6 +479,30 @@ func TestRemove(t *testing.T) {
 			require.Equal(t, len(sortedData)-i-1, sl.Length())
 		}
 	})
+	t.Run("Duplicates", func(t *testing.T) {
+		type kv struct{ key, value int }
+		lessKey := func(a, b kv) bool {
+			return a.key < b.key
+		}
+		sl := skiplist.New(lessKey)
+		for i := 0; i < 4096; i++ {
+			sl.Add(kv{key: i % 64, value: i})
+		}
+		for i := 0; i < 4096; i++ {
+			key := (i * 7) % 64
+			node := sl.Remove(kv{key: key})
+			require.NotNil(t, node)
+			require.Equal(t, key, node.Value().key)
+			require.Equal(t, 4096-i-1, sl.Length())
+			count := 0
+			for node := sl.First(); node != nil; node = node.Next() {
+				count++
+			}
+			require.Equal(t, sl.Length(), count)
+		}
+		require.Nil(t, sl.First())
+		require.Nil(t, sl.Last())
+	})
 }
 
 func TestRemoveFirst(t *testing.T) {
@@ -301,6 +524,72 @@ func TestRemoveFirst(t *testing.T) {
 	}
 }
 
+func TestRemoveLast(t *testing.T) {
+	const numElem = 1 << 16
+	sortedData := [numElem]int{}
+	for i := 0; i < numElem; i++ {
+		sortedData[i] = i
+	}
+	sl := skiplist.New(less[int])
+	addAll(t, sl, sortedData[:])
+	for i := range sortedData {
+		value := sortedData[len(sortedData)-1-i]
+		require.NotNil(t, sl.First())
+		require.NotNil(t, sl.Last())
+		require.Equal(t, value, sl.Last().Value())
+		node := sl.RemoveLast()
+		require.NotNil(t, node)
+		require.Equal(t, value, node.Value())
+		require.Equal(t, len(sortedData)-i-1, sl.Length())
+		if i%4096 == 0 {
+			requireEqual(t, sl, sortedData[:len(sortedData)-1-i])
+		}
+	}
+	require.Nil(t, sl.RemoveLast())
+	requireEqual(t, sl, []int{})
+}
+
+func TestDetachOnRemove(t *testing.T) {
+	sortedData := make([]int, 64)
+	for i := range sortedData {
+		sortedData[i] = i
+	}
+	sl := skiplist.New(
+		less[int],
+		skiplist.WithDetachOnRemove(),
+		skiplist.WithReplace(),
+	)
+	addAll(t, sl, sortedData)
+	requireDetached := func(node *skiplist.Node[int]) {
+		require.NotNil(t, node)
+		require.Nil(t, node.Next())
+		require.Nil(t, node.Prev())
+		require.Nil(t, node.RemoveFrom(sl))
+	}
+	requireDetached(sl.RemoveFirst())
+	requireDetached(sl.RemoveLast())
+	requireDetached(sl.Remove(10))
+	requireDetached(sl.Search(20).RemoveFrom(sl))
+	_, replaced := sl.Add(30)
+	requireDetached(replaced)
+	var removed *skiplist.Node[int]
+	sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+		if node.Value() == 40 {
+			removed = node
+			return skiplist.Remove
+		}
+		return skiplist.Continue
+	})
+	requireDetached(removed)
+	expectedData := []int{}
+	for _, v := range sortedData[1 : len(sortedData)-1] {
+		if v != 10 && v != 20 && v != 40 {
+			expectedData = append(expectedData, v)
+		}
+	}
+	requireEqual(t, sl, expectedData)
+}
+
 func TestSearch(t *testing.T) {
 	const numElem = 1 << 16
 	sortedData := [numElem]float64{}
@@ -322,6 +611,67 @@ func TestSearch(t *testing.T) {
 	require.Nil(t, node)
 }
 
+func TestSearchDesc(t *testing.T) {
+	const numElem = 1 << 16
+	sortedData := [numElem]float64{}
+	for i := 0; i < numElem; i++ {
+		sortedData[i] = float64(i)
+	}
+	sl := skiplist.New(less[float64])
+	addAll(t, sl, sortedData[:])
+	var node *skiplist.Node[float64]
+	for i := range sortedData {
+		node = sl.SearchDesc(sortedData[i])
+		require.NotNil(t, node)
+		require.Equal(t, sortedData[i], node.Value())
+		node = sl.SearchDesc(sortedData[i] + 0.5)
+		require.NotNil(t, node)
+		require.Equal(t, sortedData[i], node.Value())
+	}
+	node = sl.SearchDesc(sortedData[0] - 10)
+	require.Nil(t, node)
+	t.Run("Duplicates", func(t *testing.T) {
+		type kv struct{ key, value int }
+		lessKey := func(a, b kv) bool {
+			return a.key < b.key
+		}
+		sl := skiplist.New(lessKey)
+		for i := 0; i < 1024; i++ {
+			sl.Add(kv{key: i / 8, value: i})
+		}
+		for key := 0; key < 1024/8; key++ {
+			node := sl.SearchDesc(kv{key: key})
+			require.NotNil(t, node)
+			require.Equal(t, key, node.Value().key)
+			if next := node.Next(); next != nil {
+				require.Equal(t, key+1, next.Value().key)
+			}
+		}
+	})
+}
+
+func TestValues(t *testing.T) {
+	sl := skiplist.New(less[int])
+	_, ok := sl.FirstValue()
+	require.False(t, ok)
+	_, ok = sl.LastValue()
+	require.False(t, ok)
+	_, ok = sl.SearchValue(0)
+	require.False(t, ok)
+	addAll(t, sl, []int{4, 2, 6})
+	value, ok := sl.FirstValue()
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+	value, ok = sl.LastValue()
+	require.True(t, ok)
+	require.Equal(t, 6, value)
+	value, ok = sl.SearchValue(3)
+	require.True(t, ok)
+	require.Equal(t, 4, value)
+	_, ok = sl.SearchValue(7)
+	require.False(t, ok)
+}
+
 func ExampleSkipList() {
 	// var list *skiplist.SkipList[int]
 	list := skiplist.New(func(a, b int) bool { return a < b })
@@ -0,0 +1,92 @@
+// Package skiplisthttp serves debug information
+// about a skiplist over HTTP.
+//
+// The handler is intended to be mounted behind an
+// administrative port, in the same way as the handlers
+// of net/http/pprof and expvar:
+//
+//	mux.Handle("/debug/skiplist/index", skiplisthttp.Handler(list, format, &mu))
+package skiplisthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+// The maximum number of values dumped per request.
+const MaxLimit = 1000
+
+// The response of a Handler.
+type Response struct {
+	Stats skiplist.Stats `json:"stats"`
+	// The dumped values, formatted as strings.
+	Values []string `json:"values,omitempty"`
+}
+
+// Create a handler that responds with statistics about
+// the skiplist as JSON. A range of values formatted by
+// format is included when the request has a limit query
+// parameter, starting at the index given by the optional
+// offset query parameter. The limit is capped at MaxLimit.
+// The skiplist is read while holding mu, which must guard
+// it against concurrent modification. A nil mu can be used
+// if the skiplist is not modified while serving requests.
+func Handler[T any](
+	l *skiplist.SkipList[T],
+	format func(T) string,
+	mu sync.Locker,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, err := intParam(r, "offset")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit, err := intParam(r, "limit")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+		var resp Response
+		if mu != nil {
+			mu.Lock()
+		}
+		resp.Stats = l.Stats()
+		for node := l.At(offset); node != nil && len(resp.Values) < limit; node = node.Next() {
+			resp.Values = append(resp.Values, format(node.Value()))
+		}
+		if mu != nil {
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// Parse an optional non-negative integer query parameter.
+func intParam(r *http.Request, name string) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, &paramError{name: name, value: s}
+	}
+	return n, nil
+}
+
+type paramError struct {
+	name, value string
+}
+
+func (e *paramError) Error() string {
+	return "invalid " + e.name + " " + strconv.Quote(e.value)
+}
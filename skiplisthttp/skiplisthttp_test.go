@@ -0,0 +1,46 @@
+package skiplisthttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/skiplisthttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	sl := skiplist.New(func(a, b int) bool { return a < b })
+	for i := 0; i < 100; i++ {
+		sl.Add(i)
+	}
+	h := skiplisthttp.Handler(sl, strconv.Itoa, &sync.Mutex{})
+	get := func(query string) (int, skiplisthttp.Response) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+query, nil))
+		var resp skiplisthttp.Response
+		if rec.Code == http.StatusOK {
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec.Code, resp
+	}
+	code, resp := get("")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, sl.Stats(), resp.Stats)
+	require.Empty(t, resp.Values)
+	code, resp = get("?offset=10&limit=3")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []string{"10", "11", "12"}, resp.Values)
+	code, resp = get("?offset=98&limit=10")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []string{"98", "99"}, resp.Values)
+	code, _ = get("?limit=-1")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("?offset=x")
+	require.Equal(t, http.StatusBadRequest, code)
+}
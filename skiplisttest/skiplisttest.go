@@ -0,0 +1,93 @@
+// Package skiplisttest provides helpers for testing code
+// that uses skiplists, such as deterministic node levels,
+// assertions on the exact structure of a skiplist and
+// replaying recorded operations.
+package skiplisttest
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+)
+
+// Create a random number generator for skiplist.WithRng
+// that gives the nodes created by Add the given levels,
+// in order. Panics when called more times than there are
+// levels, or if a level is not in the range [1, 32].
+func LevelsFromSlice(levels ...int) func() uint32 {
+	for _, level := range levels {
+		if level < 1 || level > skiplist.MaxLevel {
+			panic(fmt.Sprintf("skiplisttest: level %d out of range", level))
+		}
+	}
+	idx := 0
+	return func() uint32 {
+		if idx == len(levels) {
+			panic("skiplisttest: levels exhausted")
+		}
+		level := levels[idx]
+		idx++
+		// the level of a node is one more than the
+		// number of consecutive low one bits.
+		return uint32(1)<<(level-1) - 1
+	}
+}
+
+// Assert that the skiplist has a consistent structure
+// where level i+1 links exactly the values of
+// expectedLevels[i], in order. The first level holds
+// every value and the levels above hold the values of
+// the nodes with at least that level.
+// Reports an error to t and returns false otherwise.
+func AssertStructure[T any](
+	t testing.TB,
+	sl *skiplist.SkipList[T],
+	expectedLevels [][]T,
+) bool {
+	t.Helper()
+	if err := sl.CheckStructure(); err != nil {
+		t.Errorf("inconsistent structure: %v", err)
+		return false
+	}
+	var levels [][]T
+	for node := sl.First(); node != nil; node = node.Next() {
+		for len(levels) < node.Level() {
+			levels = append(levels, nil)
+		}
+		for levelIdx := 0; levelIdx < node.Level(); levelIdx++ {
+			levels[levelIdx] = append(levels[levelIdx], node.Value())
+		}
+	}
+	if len(levels) != len(expectedLevels) {
+		t.Errorf("expected %d levels, got %d: %v", len(expectedLevels), len(levels), levels)
+		return false
+	}
+	for levelIdx := range levels {
+		expected := expectedLevels[levelIdx]
+		if len(expected) == 0 {
+			expected = nil
+		}
+		if !reflect.DeepEqual(expected, levels[levelIdx]) {
+			t.Errorf("level %d: expected %v, got %v", levelIdx+1, expectedLevels[levelIdx], levels[levelIdx])
+			return false
+		}
+	}
+	return true
+}
+
+// Apply the operations recorded by skiplist.WithRecorder
+// to the skiplist, in order, like SkipList.Replay.
+// Values are decoded by decode.
+// Create the skiplist with the options of the recorded
+// skiplist, including the same random number generator,
+// to reproduce its structure exactly.
+func Replay[T any](
+	r io.Reader,
+	sl *skiplist.SkipList[T],
+	decode func(value string) (T, error),
+) error {
+	return sl.Replay(r, decode)
+}
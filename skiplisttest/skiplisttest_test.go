@@ -0,0 +1,220 @@
+package skiplisttest_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/adriansahlman/skiplist/skiplisttest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelsFromSlice(t *testing.T) {
+	levels := []int{1, 3, 32, 2, 1}
+	sl := skiplist.New(
+		func(a, b int) bool { return a < b },
+		skiplist.WithRng(skiplisttest.LevelsFromSlice(levels...)),
+	)
+	for i := range levels {
+		sl.Add(i)
+	}
+	i := 0
+	for node := sl.First(); node != nil; node = node.Next() {
+		require.Equal(t, levels[i], node.Level())
+		i++
+	}
+	require.Panics(t, func() { sl.Add(len(levels)) })
+	require.Panics(t, func() { skiplisttest.LevelsFromSlice(0) })
+	require.Panics(t, func() { skiplisttest.LevelsFromSlice(33) })
+}
+
+func TestAssertStructure(t *testing.T) {
+	sl := skiplist.New(
+		func(a, b int) bool { return a < b },
+		skiplist.WithRng(skiplisttest.LevelsFromSlice(1, 3, 1, 2)),
+		skiplist.WithReplace(),
+	)
+	require.True(t, skiplisttest.AssertStructure(t, sl, nil))
+	for _, v := range []int{1, 2, 3, 4} {
+		sl.Add(v)
+	}
+	require.True(t, skiplisttest.AssertStructure(t, sl, [][]int{
+		{1, 2, 3, 4},
+		{2, 4},
+		{2},
+	}))
+	// a replaced node is removed from every level.
+	sl.AddNode(sl.NewNode(2, 1))
+	require.True(t, skiplisttest.AssertStructure(t, sl, [][]int{
+		{1, 2, 3, 4},
+		{4},
+	}))
+
+	mock := &testing.T{}
+	require.False(t, skiplisttest.AssertStructure(mock, sl, [][]int{
+		{1, 2, 3, 4},
+		{2},
+	}))
+	require.False(t, skiplisttest.AssertStructure(mock, sl, [][]int{
+		{1, 2, 3, 4},
+	}))
+}
+
+func TestReplay(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	var trace bytes.Buffer
+	sl := skiplist.New(less, skiplist.WithRecorder(&trace, strconv.Itoa))
+	for i := 0; i < 100; i++ {
+		sl.Add((i * 37) % 100)
+	}
+	sl.Remove(50)
+	sl.Remove(1000)
+	sl.RemoveFirst()
+	sl.RemoveLast()
+	sl.Drain(func(int) {})
+	sl.Add(7)
+	sl.Add(3)
+	require.Contains(t, trace.String(), "add \"37\"\n")
+	require.Contains(t, trace.String(), "removefirst\n")
+
+	replayed := skiplist.New(less)
+	require.NoError(t, skiplisttest.Replay(bytes.NewReader(trace.Bytes()), replayed, strconv.Atoi))
+	require.Equal(t, sl.FirstN(sl.Length()), replayed.FirstN(replayed.Length()))
+	var expected, got bytes.Buffer
+	require.NoError(t, sl.DumpStructure(&expected, strconv.Itoa))
+	require.NoError(t, replayed.DumpStructure(&got, strconv.Itoa))
+	require.Equal(t, expected.String(), got.String())
+
+	require.Error(t, skiplisttest.Replay(strings.NewReader("insert \"1\"\n"), replayed, strconv.Atoi))
+	require.Error(t, skiplisttest.Replay(strings.NewReader("add \"x\"\n"), replayed, strconv.Atoi))
+	require.Error(t, skiplisttest.Replay(strings.NewReader("clear \"1\"\n"), replayed, strconv.Atoi))
+}
+
+func TestReplayEveryOperation(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	weigh := func(v int) int { return v % 7 }
+	pressure := 0
+	for name, opts := range map[string][]skiplist.Option{
+		"Default":   nil,
+		"WithRanks": {skiplist.WithRanks()},
+		"WithCounts": {
+			skiplist.WithCounts(),
+			skiplist.WithTimestamps(),
+		},
+		"WithWeightLimit": {
+			skiplist.WithWeightLimit(weigh, 300, skiplist.Front),
+			skiplist.WithAdaptiveLevels(),
+			skiplist.WithRanks(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var trace bytes.Buffer
+			recorded := append([]skiplist.Option{
+				skiplist.WithRecorder(&trace, strconv.Itoa),
+				// the pressure is not known while replaying.
+				skiplist.WithMemoryPressure(func() bool {
+					pressure++
+					return pressure%5 == 0
+				}, 0.5),
+			}, opts...)
+			sl := skiplist.New(less, recorded...)
+			for i := 0; i < 200; i++ {
+				sl.Add((i * 37) % 50)
+			}
+			sl.AddNode(sl.NewNode(25, 0))
+			sl.AddNode(sl.NewNode(26, 5))
+			// nodes after the first of equal values
+			if node := sl.Search(30); node != nil && node.Next() != nil {
+				node.Next().RemoveFrom(sl)
+			}
+			i := 0
+			sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+				if i++; i%9 == 0 {
+					return skiplist.Remove
+				}
+				return skiplist.Continue
+			})
+			sl.Upsert(1000, func(old, new int) int { return old + new })
+			sl.Upsert(10, func(old, new int) int { return old + 35 })
+			if node := sl.Search(20); node != nil {
+				sl.CompareAndUpdate(node, node.Value(), 21, func(a, b int) bool { return a == b })
+			}
+			require.NoError(t, sl.ApplySortedDelta(func(yield func(skiplist.Delta[int]) bool) {
+				_ = yield(skiplist.Delta[int]{Value: 3, Delete: true}) &&
+					yield(skiplist.Delta[int]{Value: 4}) &&
+					yield(skiplist.Delta[int]{Value: 2000})
+			}))
+			stepper := sl.RebuildStepper()
+			for n := 0; n < 3; n++ {
+				stepper.Advance(0)
+			}
+			sl.Add(33)
+			for stepper.Advance(0) {
+			}
+			sl.RemoveRangeStepper(40, 45).Advance(time.Hour)
+			sl.ExpireInsertedBefore(time.Now())
+			sl.Remove(49)
+			sl.RemoveFirst()
+			sl.RemoveLast()
+			sl.Drain(func(int) {
+				if sl.Length() == 10 {
+					sl.Rebuild()
+				}
+			})
+			for i := 0; i < 100; i++ {
+				sl.Add(i % 20)
+			}
+			require.NoError(t, sl.CheckStructure())
+
+			replayed := skiplist.New(less, opts...)
+			require.NoError(t, skiplisttest.Replay(bytes.NewReader(trace.Bytes()), replayed, strconv.Atoi))
+			require.NoError(t, replayed.CheckStructure())
+			var expected, got bytes.Buffer
+			require.NoError(t, sl.DumpStructure(&expected, strconv.Itoa))
+			require.NoError(t, replayed.DumpStructure(&got, strconv.Itoa))
+			require.Equal(t, expected.String(), got.String())
+			require.Equal(t, sl.Weight(), replayed.Weight())
+			for v := 0; v < 20; v++ {
+				require.Equal(t, sl.CountOf(v), replayed.CountOf(v))
+			}
+		})
+	}
+
+	t.Run("LoadStructure", func(t *testing.T) {
+		var dump, trace bytes.Buffer
+		sl := skiplist.New(less)
+		for i := 0; i < 100; i++ {
+			sl.Add(i / 3)
+		}
+		require.NoError(t, sl.DumpStructure(&dump, strconv.Itoa))
+		loaded, err := skiplist.LoadStructure(
+			bytes.NewReader(dump.Bytes()),
+			less,
+			strconv.Atoi,
+			skiplist.WithRecorder(&trace, strconv.Itoa),
+		)
+		require.NoError(t, err)
+		replayed := skiplist.New(less)
+		require.NoError(t, skiplisttest.Replay(bytes.NewReader(trace.Bytes()), replayed, strconv.Atoi))
+		var got bytes.Buffer
+		require.NoError(t, replayed.DumpStructure(&got, strconv.Itoa))
+		require.Equal(t, dump.String(), got.String())
+		require.Equal(t, loaded.Length(), replayed.Length())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, line := range []string{
+			"remove \"1\" 0",
+			"update \"1\" 0",
+			"addnode \"1\" 33 1",
+			"relevel \"1\" -1 2",
+			"add \"1\"x",
+			"add 1",
+			"remove \"1\"",
+		} {
+			require.Error(t, skiplisttest.Replay(strings.NewReader(line), skiplist.New(less), strconv.Atoi), line)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package skiplist
+
+// Statistics about the structure of a skiplist.
+type Stats struct {
+	// The number of nodes.
+	Length int
+	// The highest level of any node.
+	MaxLevel int
+	// The number of nodes of each level, where index
+	// 0 holds the number of nodes of level 1.
+	Levels []int
+}
+
+// Collect statistics about the structure of the skiplist.
+// Complexity: O(n)
+func (l *SkipList[T]) Stats() Stats {
+	s := Stats{
+		Length: l.length,
+	}
+	// the highest level with any nodes
+	for s.MaxLevel = MaxLevel; s.MaxLevel > 0 && l.head.lanes[s.MaxLevel-1] == l.tail; s.MaxLevel-- {
+	}
+	s.Levels = make([]int, s.MaxLevel)
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		s.Levels[len(node.lanes)-1]++
+	}
+	return s
+}
+
+// Statistics about the memory of a skiplist
+// relevant to garbage collection.
+type GCStats struct {
+	// The number of nodes in the skiplist.
+	LiveNodes int
+	// The number of freed nodes kept for reuse by
+	// the allocator WithAllocator.
+	PooledNodes int
+	// The number of nodes in use and the total number
+	// of nodes in all chunks of an ArenaAllocator.
+	ArenaUsed     int
+	ArenaCapacity int
+	// The number of pointers held by the nodes of the
+	// skiplist, including its sentinels, that the garbage
+	// collector has to scan. Pointers within the values
+	// are not included.
+	Pointers int
+}
+
+// Collect statistics about the memory of the skiplist.
+// Complexity: O(n)
+func (l *SkipList[T]) GCStats() GCStats {
+	s := GCStats{
+		LiveNodes: l.length,
+		Pointers:  nodePointers(l.head) + nodePointers(l.tail),
+	}
+	for node := l.head.lanes[0]; node != l.tail; node = node.lanes[0] {
+		s.Pointers += nodePointers(node)
+	}
+	if pool, ok := l.alloc.(interface{ Pooled() int }); ok {
+		s.PooledNodes = pool.Pooled()
+	}
+	if arena, ok := l.alloc.(*ArenaAllocator[T]); ok {
+		s.ArenaUsed, s.ArenaCapacity = arena.Utilization()
+	}
+	return s
+}
+
+// Count the pointers held by a node, excluding
+// any pointers within its value.
+func nodePointers[T any](node *Node[T]) int {
+	// the slice headers of the lanes and
+	// prevs, and the pointer to the metadata.
+	n := 3 + len(node.lanes) + len(node.prevs)
+	if node.meta != nil {
+		// the slice header of the spans
+		// and the pointer to the times.
+		n += 2
+		if node.meta.times != nil {
+			// the older and newer nodes.
+			n += 2
+		}
+	}
+	return n
+}
@@ -0,0 +1,48 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	sl := skiplist.New(less[int])
+	s := sl.Stats()
+	require.Equal(t, 0, s.Length)
+	require.Equal(t, 0, s.MaxLevel)
+	require.Empty(t, s.Levels)
+	for _, level := range []int{1, 3, 1, 2, 3} {
+		sl.AddNode(sl.NewNode(sl.Length(), level))
+	}
+	s = sl.Stats()
+	require.Equal(t, 5, s.Length)
+	require.Equal(t, 3, s.MaxLevel)
+	require.Equal(t, []int{2, 1, 2}, s.Levels)
+}
+
+func TestGCStats(t *testing.T) {
+	sl := skiplist.New(less[int])
+	s := sl.GCStats()
+	require.Equal(t, 0, s.LiveNodes)
+	// the sentinels
+	require.Equal(t, 6+2*skiplist.MaxLevel, s.Pointers)
+	sl.AddNode(sl.NewNode(1, 2))
+	s = sl.GCStats()
+	require.Equal(t, 1, s.LiveNodes)
+	require.Equal(t, 6+2*skiplist.MaxLevel+3+4, s.Pointers)
+	require.Zero(t, s.PooledNodes)
+
+	arena := skiplist.NewArenaAllocator[int](8)
+	sl = skiplist.New(less[int], skiplist.WithAllocator[int](arena))
+	for i := 0; i < 10; i++ {
+		sl.Add(i)
+	}
+	sl.Free(sl.RemoveFirst())
+	s = sl.GCStats()
+	require.Equal(t, 9, s.LiveNodes)
+	require.Equal(t, 1, s.PooledNodes)
+	require.Equal(t, 9, s.ArenaUsed)
+	require.Equal(t, 16, s.ArenaCapacity)
+}
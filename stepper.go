@@ -0,0 +1,203 @@
+package skiplist
+
+import (
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// The number of steps between reads of the
+// clock when advancing a Stepper.
+const stepperClockInterval = 32
+
+// A Stepper performs an expensive operation on a skiplist
+// in slices of bounded duration, so that latency sensitive
+// loops can interleave it with other work instead of
+// pausing until it is complete. Each step takes about the
+// time of a single insertion or removal.
+type Stepper struct {
+	// Perform a step of the operation.
+	// Returns false if the operation is complete.
+	step func() bool
+	done bool
+	err  error
+}
+
+// Perform the operation until the budget is spent or the
+// operation is complete. The clock is read every 32 steps,
+// so a slice can overrun the budget by up to 32 steps and
+// at least one step is performed.
+// Returns true while there is work remaining.
+func (s *Stepper) Advance(budget time.Duration) bool {
+	if s.done {
+		return false
+	}
+	start := time.Now()
+	for steps := 1; ; steps++ {
+		if !s.step() {
+			s.done = true
+			return false
+		}
+		if steps%stepperClockInterval == 0 && time.Since(start) >= budget {
+			return true
+		}
+	}
+}
+
+// Get the error that stopped the operation, if any.
+func (s *Stepper) Err() error {
+	return s.err
+}
+
+// Create a stepper that incrementally performs Rebuild,
+// relinking one node per step. The skiplist is consistent
+// between calls to Advance and may be modified, but a
+// modification restarts the rebuild from the first node.
+// Complexity: O(n) steps
+func (l *SkipList[T]) RebuildStepper() *Stepper {
+	var (
+		// the last node relinked at each level,
+		// with the head at every level above.
+		preds [MaxLevel]*Node[T]
+		node  *Node[T]
+		idx   int
+		// the generation after the last step.
+		generation = l.generation - 1
+	)
+	s := &Stepper{}
+	s.step = func() bool {
+		if generation != l.generation {
+			for levelIdx := range preds {
+				preds[levelIdx] = l.head
+			}
+			node = l.head.lanes[0]
+			idx = 0
+		}
+		if node == l.tail {
+			l.generation++
+			return false
+		}
+		next := node.lanes[0]
+		idx++
+		level := 1 + bits.TrailingZeros(uint(idx))
+		if level > MaxLevel {
+			level = MaxLevel
+		}
+		if len(node.lanes) != level {
+			l.relevel(node, level, &preds)
+		}
+		for levelIdx := 0; levelIdx < level; levelIdx++ {
+			preds[levelIdx] = node
+		}
+		node = next
+		generation = l.generation
+		return true
+	}
+	return s
+}
+
+// Relink the node with a new level after the given
+// predecessors, which must be its predecessors for
+// every level in the skiplist.
+func (l *SkipList[T]) relevel(
+	node *Node[T],
+	level int,
+	preds *[MaxLevel]*Node[T],
+) {
+	l.recordNode("relevel", node, strconv.Itoa(level))
+	if l.ranks {
+		l.unlinkSpans(node)
+	}
+	for levelIdx, next := range node.lanes {
+		prev := node.prevs[levelIdx]
+		prev.lanes[levelIdx] = next
+		next.prevs[levelIdx] = prev
+	}
+	if l.adaptive {
+		l.levels[len(node.lanes)-1]--
+		l.levels[level-1]++
+	}
+	l.resizeLanes(node, level)
+	if l.ranks {
+		l.linkSpans(node, preds)
+	}
+	for levelIdx, pred := range preds[:level] {
+		next := pred.lanes[levelIdx]
+		node.lanes[levelIdx] = next
+		node.prevs[levelIdx] = pred
+		pred.lanes[levelIdx] = node
+		next.prevs[levelIdx] = node
+	}
+	l.generation++
+}
+
+// Create a stepper that incrementally removes every value
+// in the range [min, max], removing a single occurrence
+// per step like Remove. The skiplist may be modified
+// between calls to Advance, values added to the range
+// before the removal is complete are removed as well.
+// Complexity: O(log(n)) per step
+func (l *SkipList[T]) RemoveRangeStepper(min, max T) *Stepper {
+	var (
+		node       *Node[T]
+		generation = l.generation - 1
+	)
+	s := &Stepper{}
+	s.step = func() bool {
+		if generation != l.generation {
+			// the position may be stale
+			node = l.Search(min)
+		}
+		if node == nil || node == l.tail || l.less(max, node.value) {
+			return false
+		}
+		l.recordNode("remove", node)
+		if l.counts && node.meta.dups > 0 {
+			l.release(node)
+		} else {
+			next := node.lanes[0]
+			l.release(node)
+			node = next
+		}
+		generation = l.generation
+		return true
+	}
+	return s
+}
+
+// Create a stepper that incrementally adds the values of
+// another skiplist src to the skiplist like Add, adding a
+// single value per step. src must not be modified until the merge is
+// complete, and the merge stops with
+// ErrConcurrentModification if it is. The merge stops
+// with ErrFull if the skiplist is full WithHardLimit.
+// Average complexity: O(log(n)) per step
+func (l *SkipList[T]) MergeStepper(src *SkipList[T]) *Stepper {
+	var (
+		node = src.head.lanes[0]
+		// occurrences of the value of the
+		// node that have been added.
+		added      int
+		generation = src.generation
+	)
+	s := &Stepper{}
+	s.step = func() bool {
+		if src.generation != generation {
+			s.err = ErrConcurrentModification
+			return false
+		}
+		if node == src.tail {
+			return false
+		}
+		if inserted, _ := l.Add(node.value); inserted == nil {
+			s.err = ErrFull
+			return false
+		}
+		if added++; added >= node.Count() {
+			node = node.lanes[0]
+			added = 0
+		}
+		return true
+	}
+	return s
+}
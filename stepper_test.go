@@ -0,0 +1,95 @@
+package skiplist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepper(t *testing.T) {
+	t.Run("Rebuild", func(t *testing.T) {
+		for _, opts := range [][]skiplist.Option{
+			{},
+			{skiplist.WithRanks()},
+			{skiplist.WithAdaptiveLevels()},
+		} {
+			// every node has a level of 1
+			opts := append(opts, skiplist.WithRng(func() uint32 { return 0 }))
+			sl := skiplist.New(less[int], opts...)
+			rebuilt := skiplist.New(less[int], opts...)
+			for i := 0; i <= 1000; i++ {
+				sl.Add(i)
+				rebuilt.Add(i)
+			}
+			rebuilt.Rebuild()
+			s := sl.RebuildStepper()
+			steps := 0
+			for s.Advance(0) {
+				steps++
+				require.NoError(t, sl.CheckStructure())
+				if steps == 10 {
+					// restarts the rebuild
+					sl.Remove(1000)
+					sl.Add(1000)
+				}
+			}
+			require.NoError(t, s.Err())
+			require.False(t, s.Advance(time.Second))
+			require.NoError(t, sl.CheckStructure())
+			require.Equal(t, rebuilt.Stats(), sl.Stats())
+			require.Equal(t, 1001, sl.Length())
+		}
+	})
+	t.Run("RemoveRange", func(t *testing.T) {
+		for _, opts := range [][]skiplist.Option{
+			{},
+			{skiplist.WithCounts()},
+			{skiplist.WithRanks()},
+		} {
+			sl := skiplist.New(less[int], opts...)
+			for i := 0; i < 1000; i++ {
+				sl.Add(i % 100)
+			}
+			s := sl.RemoveRangeStepper(20, 79)
+			for s.Advance(0) {
+				require.NoError(t, sl.CheckStructure())
+				sl.Add(50)
+				sl.Add(10)
+			}
+			require.NoError(t, s.Err())
+			require.NoError(t, sl.CheckStructure())
+			node := sl.Search(20)
+			require.NotNil(t, node)
+			require.Equal(t, 80, node.Value())
+			require.Equal(t, 19, sl.SearchDesc(79).Value())
+		}
+	})
+	t.Run("Merge", func(t *testing.T) {
+		src := skiplist.New(less[int], skiplist.WithCounts())
+		sl := skiplist.New(less[int])
+		for i := 0; i < 100; i++ {
+			src.Add(i % 10)
+			sl.Add(i)
+		}
+		s := sl.MergeStepper(src)
+		for s.Advance(time.Second) {
+		}
+		require.NoError(t, s.Err())
+		require.Equal(t, 200, sl.Length())
+		require.Equal(t, 11, sl.CountOf(5))
+
+		s = sl.MergeStepper(src)
+		require.True(t, s.Advance(0))
+		src.Add(100)
+		require.False(t, s.Advance(0))
+		require.ErrorIs(t, s.Err(), skiplist.ErrConcurrentModification)
+
+		full := skiplist.New(less[int], skiplist.WithHardLimit(50))
+		s = full.MergeStepper(src)
+		require.False(t, s.Advance(time.Second))
+		require.ErrorIs(t, s.Err(), skiplist.ErrFull)
+		require.Equal(t, 50, full.Length())
+	})
+}
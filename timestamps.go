@@ -0,0 +1,108 @@
+package skiplist
+
+import "time"
+
+// Get the time the value of the node was inserted.
+// Updating the value in place, such as with Upsert or
+// CompareAndUpdate, keeps the time of insertion. Always
+// the zero time unless the skiplist was created
+// WithTimestamps.
+func (n *Node[T]) InsertedAt() time.Time {
+	if n.meta == nil || n.meta.times == nil {
+		return time.Time{}
+	}
+	return n.meta.times.inserted
+}
+
+// Get the time the value of the node was inserted or last
+// updated. WithCounts, adding another occurrence of the
+// value counts as an update. Always the zero time unless
+// the skiplist was created WithTimestamps.
+func (n *Node[T]) UpdatedAt() time.Time {
+	if n.meta == nil || n.meta.times == nil {
+		return time.Time{}
+	}
+	return n.meta.times.updated
+}
+
+// Record the time the node is linked and append it to
+// the insertion order, unless it is being moved by an
+// update in which case only the time of the update is
+// recorded.
+func (l *SkipList[T]) linkTime(node *Node[T]) {
+	now := time.Now()
+	node.meta.times.updated = now
+	if node == l.moving {
+		l.moving = nil
+		return
+	}
+	node.meta.times.inserted = now
+	node.meta.times.older = l.newest
+	node.meta.times.newer = nil
+	if l.newest != nil {
+		l.newest.meta.times.newer = node
+	} else {
+		l.oldest = node
+	}
+	l.newest = node
+}
+
+// Remove the node from the insertion order.
+func (l *SkipList[T]) unchain(node *Node[T]) {
+	older, newer := node.meta.times.older, node.meta.times.newer
+	if older != nil {
+		older.meta.times.newer = newer
+	} else {
+		l.oldest = newer
+	}
+	if newer != nil {
+		newer.meta.times.older = older
+	} else {
+		l.newest = older
+	}
+	node.meta.times.older = nil
+	node.meta.times.newer = nil
+}
+
+// Remove every value inserted before the given time,
+// oldest first, following the insertion order instead of
+// the sorted order. WithCounts, every occurrence of a value
+// is removed along with its node, regardless of when the
+// other occurrences were added.
+// Returns the number of values removed. Always 0 unless
+// the skiplist was created WithTimestamps.
+// Complexity: O(k) for k removed values
+func (l *SkipList[T]) ExpireInsertedBefore(t time.Time) int {
+	n := 0
+	for node := l.oldest; node != nil && node.meta.times.inserted.Before(t); node = l.oldest {
+		for count := node.Count(); count > 0; count-- {
+			l.recordNode("remove", node)
+			l.release(node)
+			n++
+		}
+	}
+	return n
+}
+
+// Record the time the value of the node was updated.
+func (l *SkipList[T]) touch(node *Node[T]) {
+	if l.timestamps {
+		node.meta.times.updated = time.Now()
+	}
+}
+
+var _ Option = (*withTimestamps)(nil)
+
+type withTimestamps struct{}
+
+func (o *withTimestamps) apply(opts *options) {
+	opts.timestamps = true
+}
+
+// Record the time every node is inserted and the time its
+// value is last updated, see Node.InsertedAt and
+// Node.UpdatedAt, enabling age based policies and
+// debugging without storing the times in the values.
+func WithTimestamps() Option {
+	return &withTimestamps{}
+}
@@ -0,0 +1,117 @@
+package skiplist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamps(t *testing.T) {
+	type entry struct {
+		key, value int
+	}
+	lessKey := func(a, b entry) bool { return a.key < b.key }
+	eq := func(a, b entry) bool { return a == b }
+	sl := skiplist.New(lessKey, skiplist.WithTimestamps())
+	before := time.Now()
+	node, _ := sl.Add(entry{key: 1})
+	after := time.Now()
+	inserted := node.InsertedAt()
+	require.False(t, inserted.Before(before))
+	require.False(t, inserted.After(after))
+	require.Equal(t, inserted, node.UpdatedAt())
+
+	time.Sleep(time.Millisecond)
+	require.True(t, sl.CompareAndUpdate(node, entry{key: 1}, entry{key: 1, value: 1}, eq))
+	require.Equal(t, inserted, node.InsertedAt())
+	require.True(t, node.UpdatedAt().After(inserted))
+
+	// moving the node keeps the time of insertion
+	sl.Add(entry{key: 2})
+	updated := node.UpdatedAt()
+	time.Sleep(time.Millisecond)
+	require.True(t, sl.CompareAndUpdate(node, entry{key: 1, value: 1}, entry{key: 3}, eq))
+	require.Equal(t, 3, sl.Last().Value().key)
+	require.Same(t, node, sl.Last())
+	require.Equal(t, inserted, node.InsertedAt())
+	require.True(t, node.UpdatedAt().After(updated))
+
+	t.Run("WithCounts", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithTimestamps(), skiplist.WithCounts())
+		node, _ := sl.Add(1)
+		inserted := node.InsertedAt()
+		time.Sleep(time.Millisecond)
+		sl.Add(1)
+		require.Equal(t, inserted, node.InsertedAt())
+		require.True(t, node.UpdatedAt().After(inserted))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		node, _ := skiplist.New(less[int]).Add(1)
+		require.True(t, node.InsertedAt().IsZero())
+		require.True(t, node.UpdatedAt().IsZero())
+	})
+}
+
+func TestExpireInsertedBefore(t *testing.T) {
+	type entry struct {
+		key, value int
+	}
+	lessKey := func(a, b entry) bool { return a.key < b.key }
+	eq := func(a, b entry) bool { return a == b }
+	sl := skiplist.New(lessKey, skiplist.WithTimestamps())
+	require.Zero(t, sl.ExpireInsertedBefore(time.Now()))
+	for _, key := range []int{5, 1, 9, 3} {
+		sl.Add(entry{key: key})
+	}
+	time.Sleep(time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	for _, key := range []int{4, 0, 8} {
+		sl.Add(entry{key: key})
+	}
+	// updates keep the position in the insertion order
+	node := sl.Search(entry{key: 9})
+	require.True(t, sl.CompareAndUpdate(node, entry{key: 9}, entry{key: 2}, eq))
+	// removals are taken out of the insertion order
+	sl.Remove(entry{key: 5})
+	require.Equal(t, 3, sl.ExpireInsertedBefore(cutoff))
+	keys := []int{}
+	for node := sl.First(); node != nil; node = node.Next() {
+		keys = append(keys, node.Value().key)
+	}
+	require.Equal(t, []int{0, 4, 8}, keys)
+	require.Equal(t, 3, sl.ExpireInsertedBefore(time.Now()))
+	require.Zero(t, sl.Length())
+
+	sl.Add(entry{key: 1})
+	sl.Clear()
+	require.Zero(t, sl.ExpireInsertedBefore(time.Now()))
+
+	t.Run("WithCounts", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithTimestamps(), skiplist.WithCounts())
+		sl.Add(1)
+		sl.Add(2)
+		sl.Add(1)
+		require.Equal(t, 3, sl.ExpireInsertedBefore(time.Now()))
+		require.Zero(t, sl.Length())
+		// a node moved onto an equal value is merged
+		// into it and taken out of the insertion order
+		node, _ := sl.Add(1)
+		sl.Add(2)
+		eq := func(a, b int) bool { return a == b }
+		require.True(t, sl.CompareAndUpdate(node, 1, 2, eq))
+		require.Equal(t, 1, sl.Length())
+		require.Equal(t, 2, sl.ExpireInsertedBefore(time.Now()))
+	})
+	t.Run("WithHardLimit", func(t *testing.T) {
+		sl := skiplist.New(lessKey, skiplist.WithTimestamps(), skiplist.WithHardLimit(2))
+		node, _ := sl.Add(entry{key: 1})
+		sl.Add(entry{key: 2})
+		// the node is moved, not dropped, since
+		// it is removed before it is inserted again.
+		require.True(t, sl.CompareAndUpdate(node, entry{key: 1}, entry{key: 3}, eq))
+		require.Equal(t, 2, sl.ExpireInsertedBefore(time.Now()))
+	})
+}
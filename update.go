@@ -0,0 +1,123 @@
+package skiplist
+
+// Replace the value of a node if its current value is
+// equal to the expected value according to eq.
+// The node is moved to keep the skiplist sorted if the
+// order of the new value differs from the current value,
+// in which case it is reinserted like AddNode.
+// The node must be part of the skiplist.
+// Returns false if the value of the node was not equal
+// to the expected value.
+// Average complexity: O(1) if the node keeps its
+// position, else O(log(n))
+func (l *SkipList[T]) CompareAndUpdate(
+	node *Node[T],
+	expected T,
+	value T,
+	eq func(a, b T) bool,
+) bool {
+	if !eq(node.value, expected) {
+		return false
+	}
+	l.update(node, value)
+	return true
+}
+
+// Insert a value, or if an equal value exists replace the
+// first equal value with the result of merging it with the
+// given value, in a single search. The node is moved like
+// CompareAndUpdate if the merged value changes its order.
+// Returns the inserted or merged node, or nil if the value
+// could not be inserted WithHardLimit. WithCounts, a moved
+// node is merged into any node with an equal merged value,
+// which is returned instead.
+// Average complexity: O(log(n))
+func (l *SkipList[T]) Upsert(value T, merge func(old, new T) T) *Node[T] {
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(value, &preds)
+	if node := l.equalSucc(value, &preds); node != nil {
+		return l.update(node, merge(node.value, value))
+	}
+	l.record("add", value)
+	if l.full(value, &preds) {
+		return nil
+	}
+	calls := l.callOptions(value)
+	node := l.newNode(value, l.randomLevel())
+	l.link(node, &preds, calls)
+	l.afterInsert(node)
+	return node
+}
+
+// Replace the value of a node that is part of the
+// skiplist, moving it if its position changes.
+// Returns the node holding the value, which WithCounts
+// is an existing node with an equal value if the node
+// was merged into it.
+func (l *SkipList[T]) update(node *Node[T], value T) (updated *Node[T]) {
+	l.recordNode("update", node, l.quote(value))
+	// call the functions of the options before the
+	// first change, so that a panic leaves the
+	// skiplist unmodified.
+	calls := l.callOptions(value)
+	weight := l.weighOf(node.value)
+	if l.fits(node, value) {
+		if l.bloom != nil {
+			l.addBloom(calls.hash)
+		}
+		l.weight += (calls.weight - weight) * node.Count()
+		node.value = value
+		if l.versions {
+			l.stamp(node)
+		}
+		l.touch(node)
+		l.afterInsert(node)
+		return node
+	}
+	// search before unlinking the node so that a
+	// search exceeding the budget leaves the node
+	// in place. The node may precede the new value.
+	var preds [MaxLevel]*Node[T]
+	l.searchPreds(value, &preds)
+	for levelIdx, pred := range preds[:len(node.lanes)] {
+		if pred == node {
+			preds[levelIdx] = node.prevs[levelIdx]
+		}
+	}
+	if l.timestamps {
+		// keep the time of insertion and the
+		// position in the insertion order.
+		l.moving = node
+	}
+	l.unlinkWeighed(node, weight)
+	node.value = value
+	// unlinking the node makes room for it
+	// WithHardLimit.
+	updated = node
+	if existing := l.equalSucc(value, &preds); l.counts && existing != nil {
+		updated = existing
+		l.addOccurrences(existing, node.Count(), calls.weight)
+	} else {
+		l.insert(node, &preds, calls)
+		l.afterInsert(node)
+	}
+	if l.moving == node {
+		// the node was not linked again
+		l.moving = nil
+		l.unchain(node)
+	}
+	return updated
+}
+
+// Check if the value can be stored in the node
+// without breaking the order of the skiplist.
+func (l *SkipList[T]) fits(node *Node[T], value T) bool {
+	prev, next := node.prevs[0], node.lanes[0]
+	if l.replace || l.counts {
+		// values must stay unique.
+		return (prev == l.head || l.less(prev.value, value)) &&
+			(next == l.tail || l.less(value, next.value))
+	}
+	return (prev == l.head || !l.less(value, prev.value)) &&
+		(next == l.tail || !l.less(next.value, value))
+}
@@ -0,0 +1,87 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAndUpdate(t *testing.T) {
+	type kv struct{ key, value int }
+	lessKey := func(a, b kv) bool { return a.key < b.key }
+	eq := func(a, b kv) bool { return a == b }
+	sl := skiplist.New(lessKey, skiplist.WithVersions())
+	for key := 0; key < 64; key += 2 {
+		sl.Add(kv{key: key})
+	}
+	node := sl.Search(kv{key: 10})
+	version := node.Version()
+	// value mismatch
+	require.False(t, sl.CompareAndUpdate(node, kv{key: 10, value: 1}, kv{key: 10, value: 2}, eq))
+	require.Equal(t, kv{key: 10}, node.Value())
+	// same position
+	require.True(t, sl.CompareAndUpdate(node, kv{key: 10}, kv{key: 11, value: 1}, eq))
+	require.Equal(t, kv{key: 11, value: 1}, node.Value())
+	require.Equal(t, 8, node.Prev().Value().key)
+	require.Greater(t, node.Version(), version)
+	// new position
+	require.True(t, sl.CompareAndUpdate(node, kv{key: 11, value: 1}, kv{key: 51, value: 2}, eq))
+	require.Equal(t, kv{key: 51, value: 2}, node.Value())
+	require.Equal(t, 50, node.Prev().Value().key)
+	require.Equal(t, 52, node.Next().Value().key)
+	require.Equal(t, 32, sl.Length())
+	prev := -1
+	for n := sl.First(); n != nil; n = n.Next() {
+		require.Greater(t, n.Value().key, prev)
+		prev = n.Value().key
+	}
+	require.Equal(t, node, sl.Search(kv{key: 51}))
+	require.Equal(t, 12, sl.Search(kv{key: 9}).Value().key)
+	t.Run("WithReplace", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithReplace())
+		addAll(t, sl, []int{1, 2, 3})
+		node := sl.Search(1)
+		// moving onto an existing value replaces it
+		require.True(t, sl.CompareAndUpdate(node, 1, 2, func(a, b int) bool { return a == b }))
+		requireEqual(t, sl, []int{2, 3})
+		require.Equal(t, node, sl.First())
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	lessKey := func(a, b [2]int) bool { return a[0] < b[0] }
+	sum := func(old, new [2]int) [2]int { return [2]int{old[0], old[1] + new[1]} }
+	sl := skiplist.New(lessKey)
+	for _, key := range []int{3, 1, 3, 2, 3, 1} {
+		node := sl.Upsert([2]int{key, 1}, sum)
+		require.Equal(t, key, node.Value()[0])
+	}
+	require.Equal(t, [][2]int{{1, 2}, {2, 1}, {3, 3}}, sl.FirstN(3))
+	require.Equal(t, 3, sl.Length())
+
+	// a merged value that changes order is moved.
+	node := sl.Upsert([2]int{1, 0}, func(old, new [2]int) [2]int { return [2]int{4, old[1]} })
+	require.Same(t, node, sl.Last())
+	require.Equal(t, [][2]int{{2, 1}, {3, 3}, {4, 2}}, sl.FirstN(3))
+	require.NoError(t, sl.CheckStructure())
+
+	sl = skiplist.New(lessKey, skiplist.WithHardLimit(1))
+	require.NotNil(t, sl.Upsert([2]int{1, 1}, sum))
+	require.NotNil(t, sl.Upsert([2]int{1, 1}, sum))
+	require.Nil(t, sl.Upsert([2]int{2, 1}, sum))
+
+	t.Run("WithCounts", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithCounts())
+		addAll(t, sl, []int{1, 5})
+		// the moved node is merged into the equal node.
+		node := sl.Upsert(1, func(_, _ int) int { return 5 })
+		require.Same(t, sl.First(), node)
+		require.Equal(t, 5, node.Value())
+		require.Equal(t, 2, node.Count())
+		require.Equal(t, 1, sl.Length())
+		require.NoError(t, sl.CheckStructure())
+		require.Same(t, node, node.RemoveFrom(sl))
+		require.Equal(t, 0, sl.Length())
+	})
+}
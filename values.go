@@ -0,0 +1,156 @@
+package skiplist
+
+// Get the k smallest values in ascending order.
+// Returns fewer values if the skiplist holds
+// fewer than k nodes.
+// Complexity: O(k)
+func (l *SkipList[T]) FirstN(k int) []T {
+	values := make([]T, 0, l.clampLength(k))
+	for node := l.head.lanes[0]; node != l.tail && len(values) < k; node = node.lanes[0] {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// Get the k largest values in descending order.
+// Returns fewer values if the skiplist holds
+// fewer than k nodes.
+// Complexity: O(k)
+func (l *SkipList[T]) LastN(k int) []T {
+	values := make([]T, 0, l.clampLength(k))
+	for node := l.tail.prevs[0]; node != l.head && len(values) < k; node = node.prevs[0] {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// Get the values in the range [min, max] in ascending
+// order, skipping the first offset values of the range
+// and returning at most limit values. A negative limit
+// returns all remaining values of the range.
+// Average complexity: O(log(n) + limit) WithRanks,
+// else O(log(n) + offset + limit)
+func (l *SkipList[T]) Between(min, max T, offset, limit int) []T {
+	var node *Node[T]
+	if l.ranks {
+		var index int
+		node, index = l.searchIndex(min)
+		if offset > 0 {
+			if node = l.At(index + offset); node == nil {
+				node = l.tail
+			}
+		}
+	} else {
+		if node = l.Search(min); node == nil {
+			node = l.tail
+		}
+		for ; offset > 0 && node != l.tail; offset-- {
+			node = node.lanes[0]
+		}
+	}
+	var values []T
+	for ; node != l.tail && limit != 0 && !l.less(max, node.value); node = node.lanes[0] {
+		values = append(values, node.value)
+		limit--
+	}
+	return values
+}
+
+// Get at most limit values that are less than the
+// given value in descending order. A nil value pages
+// from the last value in the skiplist.
+// Average complexity: O(log(n) + limit)
+func (l *SkipList[T]) PageBefore(before *T, limit int) []T {
+	node := l.Last()
+	if before != nil {
+		node = l.searchLT(*before)
+	}
+	values := make([]T, 0, l.clampLength(limit))
+	for ; node != nil && len(values) < limit; node = node.Prev() {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// Limit n to the range [0, length].
+func (l *SkipList[T]) clampLength(n int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > l.length {
+		return l.length
+	}
+	return n
+}
+
+// Get a sequence of approximately every k-th value in
+// ascending order, starting with the first value. The
+// sequence has the signature of an iter.Seq[T].
+// WithRanks exactly every k-th value is visited by jumping
+// along the spans of the lanes. Otherwise the values of the
+// nodes on the lane where nodes are on average k apart are
+// visited, which samples the distribution of the values
+// without visiting every node.
+// Average complexity: O(n/k*log(n)) WithRanks, else O(n/k)
+func (l *SkipList[T]) SampleEvery(k int) func(yield func(T) bool) {
+	if k < 1 {
+		k = 1
+	}
+	return func(yield func(T) bool) {
+		if l.ranks {
+			for index := 0; index < l.length; index += k {
+				l.visit()
+				if !yield(l.At(index).value) {
+					return
+				}
+			}
+			return
+		}
+		// every level has about half
+		// as many nodes as the one below.
+		levelIdx := 0
+		for levelIdx < MaxLevel-1 && 2<<levelIdx <= k {
+			levelIdx++
+		}
+		first := l.head.lanes[0]
+		if first == l.tail || !yield(first.value) {
+			return
+		}
+		for node := l.head.lanes[levelIdx]; node != l.tail; node = node.lanes[levelIdx] {
+			l.visit()
+			if node != first && !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Get two sequences expanding outward from the pivot, asc
+// with the values greater than or equal to the pivot in
+// ascending order and desc with the values less than the
+// pivot in descending order, so that every value is in
+// exactly one of them. The sequences have the signature of
+// an iter.Seq[T] and can be pulled from in turns, such as
+// with iter.Pull, to visit the values nearest the pivot
+// first.
+// Average complexity: O(log(n)) to start each sequence,
+// then O(1) per value
+func (l *SkipList[T]) AroundPivot(pivot T) (asc, desc func(yield func(T) bool)) {
+	asc = func(yield func(T) bool) {
+		for node := l.Search(pivot); node != nil; node = node.Next() {
+			l.visit()
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+	desc = func(yield func(T) bool) {
+		for node := l.searchLT(pivot); node != nil; node = node.Prev() {
+			l.visit()
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+	return asc, desc
+}
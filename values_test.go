@@ -0,0 +1,176 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstN(t *testing.T) {
+	sl := skiplist.New(less[int])
+	require.Empty(t, sl.FirstN(10))
+	addAll(t, sl, []int{5, 1, 4, 2, 3})
+	require.Equal(t, []int{1, 2, 3}, sl.FirstN(3))
+	require.Equal(t, []int{1, 2, 3, 4, 5}, sl.FirstN(10))
+	require.Empty(t, sl.FirstN(0))
+	require.Empty(t, sl.FirstN(-1))
+	values := sl.FirstN(2)
+	require.Equal(t, 2, cap(values))
+}
+
+func TestLastN(t *testing.T) {
+	sl := skiplist.New(less[int])
+	require.Empty(t, sl.LastN(10))
+	addAll(t, sl, []int{5, 1, 4, 2, 3})
+	require.Equal(t, []int{5, 4, 3}, sl.LastN(3))
+	require.Equal(t, []int{5, 4, 3, 2, 1}, sl.LastN(10))
+	require.Empty(t, sl.LastN(0))
+	require.Empty(t, sl.LastN(-1))
+	values := sl.LastN(10)
+	require.Equal(t, 5, cap(values))
+}
+
+func TestBetween(t *testing.T) {
+	sortedData := make([]int, 1024)
+	for i := range sortedData {
+		sortedData[i] = 2 * i
+	}
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+		sl := skiplist.New(less[int], opts...)
+		require.Empty(t, sl.Between(0, 100, 0, 10))
+		addAll(t, sl, sortedData)
+		require.Equal(t, []int{10, 12, 14, 16}, sl.Between(10, 16, 0, -1))
+		require.Equal(t, []int{10, 12, 14, 16}, sl.Between(9, 17, 0, 10))
+		require.Equal(t, []int{14, 16}, sl.Between(10, 16, 2, 10))
+		require.Equal(t, []int{12, 14}, sl.Between(10, 16, 1, 2))
+		require.Empty(t, sl.Between(10, 16, 4, 10))
+		require.Empty(t, sl.Between(10, 16, 0, 0))
+		require.Empty(t, sl.Between(11, 11, 0, 10))
+		require.Empty(t, sl.Between(5000, 6000, 0, 10))
+		require.Empty(t, sl.Between(0, 2046, 5000, 10))
+		require.Equal(t, sortedData[100:], sl.Between(-10, 5000, 100, -1))
+		for offset := 0; offset < len(sortedData); offset += 97 {
+			require.Equal(
+				t,
+				sortedData[offset:offset+3],
+				sl.Between(0, 2046, offset, 3),
+			)
+		}
+	}
+}
+
+func TestPageBefore(t *testing.T) {
+	sl := skiplist.New(less[int])
+	require.Empty(t, sl.PageBefore(nil, 10))
+	addAll(t, sl, []int{1, 3, 5, 7, 9})
+	before := func(v int) *int { return &v }
+	require.Equal(t, []int{9, 7}, sl.PageBefore(nil, 2))
+	require.Equal(t, []int{5, 3}, sl.PageBefore(before(7), 2))
+	require.Equal(t, []int{7, 5}, sl.PageBefore(before(8), 2))
+	require.Equal(t, []int{3, 1}, sl.PageBefore(before(5), 10))
+	require.Empty(t, sl.PageBefore(before(1), 10))
+	require.Empty(t, sl.PageBefore(before(5), 0))
+	// paging through all values
+	values := []int{}
+	page := sl.PageBefore(nil, 2)
+	for len(page) > 0 {
+		values = append(values, page...)
+		page = sl.PageBefore(&page[len(page)-1], 2)
+	}
+	require.Equal(t, []int{9, 7, 5, 3, 1}, values)
+}
+
+func TestRangeBounds(t *testing.T) {
+	sl := skiplist.New(less[int])
+	first, last := sl.RangeBounds(0, 10)
+	require.Nil(t, first)
+	require.Nil(t, last)
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = 2 * i
+	}
+	addAll(t, sl, data)
+	for _, bounds := range [][2]int{{100, 200}, {101, 199}, {-10, 0}, {-10, 5000}, {1998, 5000}, {3, 3}, {4, 4}, {5, 2}, {2000, 3000}, {-5, -1}} {
+		first, last := sl.RangeBounds(bounds[0], bounds[1])
+		values := sl.Between(bounds[0], bounds[1], 0, -1)
+		if len(values) == 0 {
+			require.Nil(t, first, bounds)
+			require.Nil(t, last, bounds)
+			continue
+		}
+		require.Equal(t, values[0], first.Value(), bounds)
+		require.Equal(t, values[len(values)-1], last.Value(), bounds)
+	}
+}
+
+func TestSampleEvery(t *testing.T) {
+	collect := func(seq func(yield func(int) bool)) []int {
+		values := []int{}
+		seq(func(v int) bool {
+			values = append(values, v)
+			return true
+		})
+		return values
+	}
+	for _, opts := range [][]skiplist.Option{nil, {skiplist.WithRanks()}} {
+		sl := skiplist.New(less[int], opts...)
+		require.Empty(t, collect(sl.SampleEvery(10)))
+		for i := 0; i < 10000; i++ {
+			sl.Add(i)
+		}
+		require.Len(t, collect(sl.SampleEvery(1)), 10000)
+		values := collect(sl.SampleEvery(100))
+		require.Equal(t, 0, values[0])
+		require.InDelta(t, 100, len(values), 50)
+		require.IsIncreasing(t, values)
+		if opts != nil {
+			for i, v := range values {
+				require.Equal(t, 100*i, v)
+			}
+		}
+		// stopping early
+		n := 0
+		sl.SampleEvery(10)(func(int) bool {
+			n++
+			return n < 3
+		})
+		require.Equal(t, 3, n)
+	}
+}
+
+func TestAroundPivot(t *testing.T) {
+	collect := func(seq func(yield func(int) bool)) []int {
+		values := []int{}
+		seq(func(v int) bool {
+			values = append(values, v)
+			return true
+		})
+		return values
+	}
+	sl := skiplist.New(less[int])
+	asc, desc := sl.AroundPivot(5)
+	require.Empty(t, collect(asc))
+	require.Empty(t, collect(desc))
+	addAll(t, sl, []int{1, 3, 5, 5, 7, 9})
+	asc, desc = sl.AroundPivot(5)
+	require.Equal(t, []int{5, 5, 7, 9}, collect(asc))
+	require.Equal(t, []int{3, 1}, collect(desc))
+	asc, desc = sl.AroundPivot(6)
+	require.Equal(t, []int{7, 9}, collect(asc))
+	require.Equal(t, []int{5, 5, 3, 1}, collect(desc))
+	asc, desc = sl.AroundPivot(0)
+	require.Equal(t, []int{1, 3, 5, 5, 7, 9}, collect(asc))
+	require.Empty(t, collect(desc))
+	// the sequences start from the current contents
+	asc, desc = sl.AroundPivot(5)
+	sl.Add(4)
+	require.Equal(t, []int{4, 3, 1}, collect(desc))
+	// stopping early
+	n := 0
+	asc(func(int) bool {
+		n++
+		return false
+	})
+	require.Equal(t, 1, n)
+}
@@ -0,0 +1,37 @@
+package skiplist
+
+// Get the version stamped on the node when its value was
+// inserted or last updated, or WithCounts when its count
+// was last increased. Versions are increasing within
+// a skiplist. Always 0 unless the skiplist was created
+// WithVersions.
+func (n *Node[T]) Version() uint64 {
+	if n.meta == nil {
+		return 0
+	}
+	return n.meta.version
+}
+
+// Stamp the node with the next version.
+func (l *SkipList[T]) stamp(node *Node[T]) {
+	l.version++
+	node.meta.version = l.version
+}
+
+var _ Option = (*withVersions)(nil)
+
+type withVersions struct{}
+
+func (o *withVersions) apply(opts *options) {
+	opts.versions = true
+}
+
+// Stamp every node with an increasing version when its
+// value is inserted or updated, see Node.Version.
+// WithCounts, adding another occurrence of the value
+// of a node also stamps the node.
+// Copies of values can be checked for staleness by
+// comparing the version they were copied at.
+func WithVersions() Option {
+	return &withVersions{}
+}
@@ -0,0 +1,47 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions(t *testing.T) {
+	sl := skiplist.New(less[int], skiplist.WithVersions(), skiplist.WithReplace())
+	var last uint64
+	for _, v := range []int{5, 3, 8, 1} {
+		node, _ := sl.Add(v)
+		require.Greater(t, node.Version(), last)
+		last = node.Version()
+	}
+	node, replaced := sl.Add(3)
+	require.NotNil(t, replaced)
+	require.Greater(t, node.Version(), replaced.Version())
+	// reinserting a node stamps a new version
+	removed := sl.RemoveFirst()
+	version := removed.Version()
+	removed.Reset(removed.Value())
+	require.Equal(t, uint64(0), removed.Version())
+	sl.AddNode(removed)
+	require.Greater(t, removed.Version(), version)
+	t.Run("WithCounts", func(t *testing.T) {
+		sl := skiplist.New(less[int], skiplist.WithVersions(), skiplist.WithCounts())
+		node, _ := sl.Add(1)
+		version := node.Version()
+		sl.Add(2)
+		// adding an occurrence counts as
+		// an update of the node.
+		dup, _ := sl.Add(1)
+		require.Same(t, node, dup)
+		require.Greater(t, node.Version(), sl.Search(2).Version())
+		sl.AddNode(sl.NewNode(1, 1))
+		require.Greater(t, node.Version(), version+2)
+		require.Equal(t, 3, node.Count())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		node, _ := sl.Add(1)
+		require.Equal(t, uint64(0), node.Version())
+	})
+}
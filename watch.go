@@ -0,0 +1,77 @@
+package skiplist
+
+import "sync"
+
+// A subscriber to values inserted within a range.
+type watcher[T any] struct {
+	min, max T
+	values   chan T
+	// Guards sending on and closing the channel, as the
+	// subscription may be cancelled while a value is sent.
+	mu        sync.Mutex
+	cancelled bool
+}
+
+// Subscribe to values inserted within the range [min, max].
+// Every value added, or updated, within the range and still
+// in the skiplist once the insertion is complete, such as
+// after evicting values WithWeightLimit, is sent on the
+// returned channel. The channel buffers the given number of
+// values and insertions never wait for the subscriber:
+// values inserted while the buffer is full are dropped.
+// Calling cancel stops the subscription and closes the
+// channel. Cancel may be called from any goroutine, also
+// concurrently with insertions, while Watch must not be
+// called concurrently with other methods of the skiplist.
+func (l *SkipList[T]) Watch(min, max T, buffer int) (values <-chan T, cancel func()) {
+	w := &watcher[T]{
+		min:    min,
+		max:    max,
+		values: make(chan T, buffer),
+	}
+	l.watchers = append(l.watchers, w)
+	return w.values, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if !w.cancelled {
+			w.cancelled = true
+			close(w.values)
+		}
+	}
+}
+
+// Send the value to all subscribers of a range that
+// contains the value, without waiting for them.
+func (l *SkipList[T]) notify(value T) {
+	l.pruneWatchers()
+	for _, w := range l.watchers {
+		if l.less(value, w.min) || l.less(w.max, value) {
+			continue
+		}
+		w.mu.Lock()
+		if !w.cancelled {
+			select {
+			case w.values <- value:
+			default:
+				// the buffer is full.
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Drop the cancelled subscriptions.
+func (l *SkipList[T]) pruneWatchers() {
+	watchers := l.watchers[:0]
+	for _, w := range l.watchers {
+		w.mu.Lock()
+		if !w.cancelled {
+			watchers = append(watchers, w)
+		}
+		w.mu.Unlock()
+	}
+	for i := len(watchers); i < len(l.watchers); i++ {
+		l.watchers[i] = nil
+	}
+	l.watchers = watchers
+}
@@ -0,0 +1,95 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	sl := skiplist.New(less[int])
+	values, cancel := sl.Watch(10, 20, 0)
+	received := make(chan []int)
+	go func() {
+		got := []int{}
+		for v := range values {
+			got = append(got, v)
+		}
+		received <- got
+	}()
+	// the insertions do not wait for the subscriber,
+	// so an unbuffered channel may drop values.
+	for i := 0; i < 32; i++ {
+		sl.Add(i)
+	}
+	cancel()
+	for _, v := range <-received {
+		require.True(t, v >= 10 && v <= 20)
+	}
+	// cancelling twice is a no-op
+	cancel()
+
+	t.Run("Buffered", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		// the subscriber may insert values itself
+		values, cancel := sl.Watch(10, 20, 16)
+		for i := 0; i < 32; i++ {
+			sl.Add(i)
+		}
+		node := sl.NewNode(15, 0)
+		sl.AddNode(node)
+		sl.CompareAndUpdate(node, 15, 25, func(a, b int) bool { return a == b })
+		cancel()
+		// values inserted after cancelling are not sent
+		sl.Add(15)
+		got := []int{}
+		for v := range values {
+			got = append(got, v)
+		}
+		expected := []int{}
+		for i := 10; i <= 20; i++ {
+			expected = append(expected, i)
+		}
+		expected = append(expected, 15)
+		require.Equal(t, expected, got)
+	})
+	t.Run("Full", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		values, cancel := sl.Watch(0, 100, 2)
+		addAll(t, sl, []int{1, 2, 3})
+		cancel()
+		require.Equal(t, 1, <-values)
+		require.Equal(t, 2, <-values)
+		_, ok := <-values
+		require.False(t, ok)
+	})
+	t.Run("CancelConcurrently", func(t *testing.T) {
+		sl := skiplist.New(less[int])
+		_, cancel := sl.Watch(0, 1000, 1)
+		done := make(chan struct{})
+		go func() {
+			cancel()
+			close(done)
+		}()
+		for i := 0; i < 1000; i++ {
+			sl.Add(i)
+		}
+		<-done
+	})
+	t.Run("Evicted", func(t *testing.T) {
+		sl := skiplist.New(
+			less[int],
+			skiplist.WithWeightLimit(func(int) int { return 1 }, 2, skiplist.Back),
+		)
+		values, cancel := sl.Watch(0, 100, 10)
+		addAll(t, sl, []int{1, 2, 3, 0})
+		cancel()
+		got := []int{}
+		for v := range values {
+			got = append(got, v)
+		}
+		// 3 is evicted as soon as it is inserted
+		require.Equal(t, []int{1, 2, 0}, got)
+	})
+}
@@ -0,0 +1,95 @@
+package skiplist
+
+// An end of a skiplist.
+type End int
+
+const (
+	// The end holding the smallest values.
+	Front End = iota
+	// The end holding the largest values.
+	Back
+)
+
+// Get the total weight of all values in a skiplist
+// created WithWeightLimit. Always 0 otherwise.
+func (l *SkipList[T]) Weight() int {
+	return l.weight
+}
+
+// Get the weight of a value, or 0 unless
+// the skiplist was created WithWeightLimit.
+func (l *SkipList[T]) weighOf(value T) int {
+	if l.weigh == nil {
+		return 0
+	}
+	return l.weigh(value)
+}
+
+// Called after a value has been inserted into or
+// updated in the node.
+func (l *SkipList[T]) afterInsert(node *Node[T]) {
+	evicted := l.enforceWeightLimit(node)
+	if len(l.watchers) > 0 && !evicted {
+		l.notify(node.value)
+	}
+	if l.adaptive {
+		l.adapt()
+	}
+}
+
+// Evict values from the configured end until the
+// total weight is within the limit.
+// Returns whether the node was removed.
+func (l *SkipList[T]) enforceWeightLimit(node *Node[T]) (evicted bool) {
+	if l.weigh == nil || l.replaying {
+		return false
+	}
+	limit := l.weightLimit
+	if l.pressured != nil && l.pressured() {
+		limit = int(float64(limit) * l.pressureFactor)
+	}
+	for l.weight > limit && l.length > 0 {
+		victim := l.tail.prevs[0]
+		if l.evictFrom == Front {
+			victim = l.head.lanes[0]
+		}
+		if victim == node && node.Count() == 1 {
+			evicted = true
+		}
+		l.recordNode("remove", victim)
+		l.release(victim)
+	}
+	return evicted
+}
+
+var _ Option = (*withWeightLimit)(nil)
+
+type withWeightLimit struct {
+	weigh any
+	limit int
+	from  End
+}
+
+func (o *withWeightLimit) apply(opts *options) {
+	opts.weigh = o.weigh
+	opts.weightLimit = o.limit
+	opts.evictFrom = o.from
+}
+
+// Keep track of the total weight of all values in the
+// skiplist, and after every insertion evict values from
+// the given end until the total weight no longer exceeds
+// the limit. A value heavier than the limit is evicted
+// right away if it ends up at the evicting end.
+// The type parameter must match that of the skiplist.
+func WithWeightLimit[T any](
+	weigh func(T) int,
+	limit int,
+	from End,
+) Option {
+	return &withWeightLimit{
+		weigh: weigh,
+		limit: limit,
+		from:  from,
+	}
+}
@@ -0,0 +1,130 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightLimit(t *testing.T) {
+	type entry struct {
+		key  int
+		size int
+	}
+	lessKey := func(a, b entry) bool { return a.key < b.key }
+	size := func(e entry) int { return e.size }
+	t.Run("Front", func(t *testing.T) {
+		sl := skiplist.New(lessKey, skiplist.WithWeightLimit(size, 100, skiplist.Front))
+		for key := 0; key < 100; key++ {
+			sl.Add(entry{key: key, size: 10})
+			require.LessOrEqual(t, sl.Weight(), 100)
+		}
+		require.Equal(t, 100, sl.Weight())
+		require.Equal(t, 10, sl.Length())
+		require.Equal(t, 90, sl.First().Value().key)
+		// a heavy value evicts several lighter ones
+		sl.Add(entry{key: 100, size: 35})
+		require.Equal(t, 95, sl.Weight())
+		require.Equal(t, 7, sl.Length())
+		require.Equal(t, 94, sl.First().Value().key)
+		sl.Remove(entry{key: 100})
+		require.Equal(t, 60, sl.Weight())
+		sl.Clear()
+		require.Equal(t, 0, sl.Weight())
+	})
+	t.Run("Back", func(t *testing.T) {
+		sl := skiplist.New(lessKey, skiplist.WithWeightLimit(size, 100, skiplist.Back))
+		for key := 0; key < 100; key++ {
+			sl.Add(entry{key: key, size: 10})
+		}
+		require.Equal(t, 100, sl.Weight())
+		require.Equal(t, 9, sl.Last().Value().key)
+		// values heavier than the limit are never kept
+		sl.Add(entry{key: 200, size: 1000})
+		require.Equal(t, 100, sl.Weight())
+		require.Equal(t, 9, sl.Last().Value().key)
+	})
+	t.Run("WithCounts", func(t *testing.T) {
+		sl := skiplist.New(
+			lessKey,
+			skiplist.WithCounts(),
+			skiplist.WithWeightLimit(size, 100, skiplist.Front),
+		)
+		for i := 0; i < 5; i++ {
+			sl.Add(entry{key: 1, size: 10})
+			sl.Add(entry{key: 2, size: 10})
+		}
+		require.Equal(t, 100, sl.Weight())
+		sl.Add(entry{key: 3, size: 20})
+		require.Equal(t, 100, sl.Weight())
+		require.Equal(t, 3, sl.CountOf(entry{key: 1}))
+		require.NotNil(t, sl.First().RemoveFrom(sl))
+		require.Equal(t, 70, sl.Weight())
+		require.NotNil(t, sl.Remove(entry{key: 2}))
+		require.Equal(t, 60, sl.Weight())
+	})
+	t.Run("TypeMismatch", func(t *testing.T) {
+		require.Panics(t, func() {
+			skiplist.New(less[int], skiplist.WithWeightLimit(size, 100, skiplist.Front))
+		})
+	})
+}
+
+func TestMemoryPressure(t *testing.T) {
+	pressured := false
+	sl := skiplist.New(
+		less[int],
+		skiplist.WithWeightLimit(func(int) int { return 1 }, 100, skiplist.Front),
+		skiplist.WithMemoryPressure(func() bool { return pressured }, 0.5),
+	)
+	for value := 0; value < 200; value++ {
+		sl.Add(value)
+	}
+	require.Equal(t, 100, sl.Length())
+	pressured = true
+	sl.Add(200)
+	require.Equal(t, 50, sl.Length())
+	require.Equal(t, 151, sl.First().Value())
+	pressured = false
+	for value := 201; value < 300; value++ {
+		sl.Add(value)
+	}
+	require.Equal(t, 100, sl.Length())
+	t.Run("MemoryLimitPressure", func(t *testing.T) {
+		require.True(t, skiplist.MemoryLimitPressure(0)())
+		require.False(t, skiplist.MemoryLimitPressure(1)())
+
+		used := uint64(0)
+		restore := skiplist.SetReadMemory(func() (uint64, int64) { return used, 1000 })
+		defer restore()
+		sl := skiplist.New(
+			less[int],
+			skiplist.WithWeightLimit(func(int) int { return 2 }, 200, skiplist.Front),
+			skiplist.WithMemoryPressure(skiplist.MemoryLimitPressure(0.8), 0.25),
+		)
+		// the memory is sampled by the first
+		// call and then every 1024 calls.
+		value := 0
+		addUntil := func(calls int) {
+			for ; value < calls; value++ {
+				sl.Add(value)
+			}
+		}
+		addUntil(1)
+		require.Equal(t, 2, sl.Weight())
+		used = 900
+		addUntil(1024)
+		require.Equal(t, 200, sl.Weight())
+		addUntil(1025)
+		require.Equal(t, 50, sl.Weight())
+		require.Equal(t, 1000, sl.First().Value())
+		used = 800
+		addUntil(2048)
+		require.Equal(t, 50, sl.Weight())
+		addUntil(2049)
+		require.Equal(t, 52, sl.Weight())
+		addUntil(3000)
+		require.Equal(t, 200, sl.Weight())
+	})
+}
@@ -0,0 +1,110 @@
+// Package wfq provides a weighted fair queue backed by a
+// skiplist ordered by virtual finish time.
+//
+// Items are enqueued to flows, and each backlogged flow is
+// served in proportion to its weight. The queue uses the
+// self-clocked variant of weighted fair queuing, where the
+// virtual time is the finish time of the item most
+// recently dequeued, which avoids simulating the fluid
+// system while keeping the same long-term fairness.
+package wfq
+
+import (
+	"github.com/adriansahlman/skiplist"
+)
+
+// A Queue is a weighted fair queue of items of type I
+// belonging to flows identified by values of type F.
+// It is not threadsafe.
+type Queue[F comparable, I any] struct {
+	list *skiplist.SkipList[entry[F, I]]
+	// The virtual time, the finish time of
+	// the item most recently dequeued.
+	now float64
+	// Orders items with equal finish times
+	// by the order they were enqueued.
+	seq   uint64
+	flows map[F]*flow
+}
+
+type entry[F comparable, I any] struct {
+	flow   F
+	item   I
+	finish float64
+	seq    uint64
+}
+
+// The state of a flow with queued items.
+type flow struct {
+	// The finish time of the last queued item.
+	finish float64
+	queued int
+}
+
+// Create an empty weighted fair queue.
+func New[F comparable, I any]() *Queue[F, I] {
+	return &Queue[F, I]{
+		list: skiplist.New(func(a, b entry[F, I]) bool {
+			return a.finish < b.finish || (a.finish == b.finish && a.seq < b.seq)
+		}),
+		flows: make(map[F]*flow),
+	}
+}
+
+// Get the number of queued items.
+func (q *Queue[F, I]) Len() int {
+	return q.list.Length()
+}
+
+// Add an item to the flow. While the flow has queued items
+// it is served in proportion to the given weight relative
+// to the weights of the other backlogged flows, where the
+// weight of the flow is the weight of its latest item.
+// A flow without queued items gains no credit for the time
+// it was idle. Panics if the weight is not positive.
+// Average complexity: O(log(n))
+func (q *Queue[F, I]) Enqueue(f F, item I, weight float64) {
+	if !(weight > 0) {
+		panic("wfq: weight must be positive")
+	}
+	state := q.flows[f]
+	if state == nil {
+		state = &flow{}
+		q.flows[f] = state
+	}
+	// an item starts when the previous item of the flow
+	// finishes, or now if the flow has caught up.
+	start := state.finish
+	if start < q.now {
+		start = q.now
+	}
+	state.finish = start + 1/weight
+	state.queued++
+	q.seq++
+	q.list.Add(entry[F, I]{
+		flow:   f,
+		item:   item,
+		finish: state.finish,
+		seq:    q.seq,
+	})
+}
+
+// Remove and return the item with the earliest virtual
+// finish time along with its flow.
+// Returns false if the queue is empty.
+// Average complexity: O(1)
+func (q *Queue[F, I]) Dequeue() (f F, item I, ok bool) {
+	node := q.list.RemoveFirst()
+	if node == nil {
+		return f, item, false
+	}
+	e := node.Value()
+	q.now = e.finish
+	state := q.flows[e.flow]
+	if state.queued--; state.queued == 0 {
+		// the finish time of the flow is at most the
+		// virtual time, so the state is not needed.
+		delete(q.flows, e.flow)
+	}
+	return e.flow, e.item, true
+}
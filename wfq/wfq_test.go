@@ -0,0 +1,49 @@
+package wfq_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist/wfq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue(t *testing.T) {
+	q := wfq.New[string, int]()
+	_, _, ok := q.Dequeue()
+	require.False(t, ok)
+	for i := 0; i < 100; i++ {
+		q.Enqueue("a", i, 1)
+		q.Enqueue("b", i, 3)
+	}
+	require.Equal(t, 200, q.Len())
+	served := map[string]int{}
+	next := map[string]int{}
+	for i := 0; i < 80; i++ {
+		flow, item, ok := q.Dequeue()
+		require.True(t, ok)
+		// items of a flow are served in order
+		require.Equal(t, next[flow], item)
+		next[flow]++
+		served[flow]++
+	}
+	require.InDelta(t, 20, served["a"], 1)
+	require.InDelta(t, 60, served["b"], 1)
+
+	// a flow gains no credit for being idle
+	for i := 0; i < 10; i++ {
+		q.Enqueue("c", i, 1)
+	}
+	served = map[string]int{}
+	for i := 0; i < 30; i++ {
+		flow, _, _ := q.Dequeue()
+		served[flow]++
+	}
+	require.InDelta(t, 6, served["a"], 1)
+	require.InDelta(t, 6, served["c"], 1)
+	require.InDelta(t, 18, served["b"], 1)
+
+	for q.Len() > 0 {
+		q.Dequeue()
+	}
+	require.Panics(t, func() { q.Enqueue("a", 0, 0) })
+}
@@ -0,0 +1,38 @@
+package skiplist
+
+import "runtime"
+
+// Yields the processor WithYieldEvery,
+// replaced by tests to count the yields.
+var gosched = runtime.Gosched
+
+// Count a node visited by an iteration and yield the
+// processor every yieldEvery visits WithYieldEvery.
+func (l *SkipList[T]) visit() {
+	if l.yieldEvery == 0 {
+		return
+	}
+	if l.visits++; l.visits >= l.yieldEvery {
+		l.visits = 0
+		gosched()
+	}
+}
+
+var _ Option = (*withYieldEvery)(nil)
+
+type withYieldEvery struct {
+	n int
+}
+
+func (o *withYieldEvery) apply(opts *options) {
+	opts.yieldEvery = o.n
+}
+
+// Call runtime.Gosched every n nodes visited by Iter,
+// Scanner, ForEachSafe, Drain, MergeWith and SampleEvery,
+// so that scans over huge skiplists do not starve other
+// goroutines on a busy scheduler. The visits are counted
+// across all iterations of the skiplist.
+func WithYieldEvery(n int) Option {
+	return &withYieldEvery{n: n}
+}
@@ -0,0 +1,43 @@
+package skiplist_test
+
+import (
+	"testing"
+
+	"github.com/adriansahlman/skiplist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYieldEvery(t *testing.T) {
+	yields := 0
+	defer skiplist.SetGosched(func() { yields++ })()
+	sl := skiplist.New(less[int], skiplist.WithYieldEvery(100))
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	visited := 0
+	sl.ForEachSafe(func(node *skiplist.Node[int]) skiplist.Action {
+		if visited++; visited == 250 {
+			return skiplist.Stop
+		}
+		return skiplist.Continue
+	})
+	require.Equal(t, 2, yields)
+
+	// the visits are counted across iterations.
+	it := sl.NewIter(nil, nil)
+	for it.Next() {
+	}
+	require.Equal(t, 12, yields)
+	s := sl.Scan()
+	for visited = 0; visited < 50 && s.Next(); visited++ {
+	}
+	require.Equal(t, 13, yields)
+
+	yields = 0
+	sl = skiplist.New(less[int])
+	for i := 0; i < 1000; i++ {
+		sl.Add(i)
+	}
+	sl.ForEachSafe(func(*skiplist.Node[int]) skiplist.Action { return skiplist.Continue })
+	require.Zero(t, yields)
+}